@@ -0,0 +1,24 @@
+package humanslog
+
+import "strings"
+
+// asciiGlyphs replaces the glyphs of markers, rules, ellipses and arrows with ASCII equivalents
+var asciiGlyphs = strings.NewReplacer(
+	"…", "...",
+	"→", "->",
+	"←", "<-",
+	"↳", "->",
+	"•", "*",
+	"×", "x",
+	"≈", "~",
+	"─", "-",
+)
+
+// glyphs returns s with its non-ASCII glyphs replaced when ASCIIOnly is set
+func (h *Handler) glyphs(s string) string {
+	if !h.opts.ASCIIOnly {
+		return s
+	}
+
+	return asciiGlyphs.Replace(s)
+}
@@ -0,0 +1,55 @@
+package humanslog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+)
+
+func Test_ASCIIOnly(t *testing.T) {
+	testASCIIOnlyErrorBlock(t)
+	testASCIIOnlyCollapsedGroup(t)
+	testASCIIOnlyDisabled(t)
+}
+
+func testASCIIOnlyErrorBlock(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, ErrorBlock: true, ASCIIOnly: true}))
+
+	err := fmt.Errorf("save user: %w", errors.New("connection refused"))
+	logger.Error("request failed", "cause", err)
+
+	expected := "[]  ERROR  request failed\n  cause: save user\n    -> connection refused\n\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testASCIIOnlyCollapsedGroup(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, CollapseGroupsOver: 1, ASCIIOnly: true}))
+
+	logger.Info("msg", slog.Group("g", "a", 1, "b", 2))
+
+	expected := "[]  INFO  msg g={... 2 attrs}\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testASCIIOnlyDisabled(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, CollapseGroupsOver: 1}))
+
+	logger.Info("msg", slog.Group("g", "a", 1, "b", 2))
+
+	expected := "[]  INFO  msg g={… 2 attrs}\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
@@ -0,0 +1,140 @@
+package humanslog
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// asyncWriter writes rendered records from a bounded queue in a background goroutine
+type asyncWriter struct {
+	queue chan asyncItem
+	drop  bool
+	write func(b []byte) error
+
+	mu     sync.RWMutex
+	closed bool
+	done   chan struct{}
+
+	dropped atomic.Int64
+	errMu   sync.Mutex
+	err     error
+}
+
+// asyncItem is a record to write, or a flush request when flushed is set
+type asyncItem struct {
+	b       *[]byte
+	flushed chan struct{}
+}
+
+func newAsyncWriter(size int, drop bool, write func(b []byte) error) *asyncWriter {
+	a := &asyncWriter{
+		queue: make(chan asyncItem, size),
+		drop:  drop,
+		write: write,
+		done:  make(chan struct{}),
+	}
+
+	go a.run()
+
+	return a
+}
+
+func (a *asyncWriter) run() {
+	defer close(a.done)
+
+	for item := range a.queue {
+		if item.flushed != nil {
+			close(item.flushed)
+			continue
+		}
+
+		a.setErr(a.write(*item.b))
+		putBuffer(item.b)
+
+		if n := a.dropped.Swap(0); n > 0 {
+			a.setErr(a.write([]byte("humanslog: dropped " + strconv.FormatInt(n, 10) + " records, the async queue was full\n")))
+		}
+	}
+}
+
+func (a *asyncWriter) setErr(err error) {
+	if err == nil {
+		return
+	}
+
+	a.errMu.Lock()
+	defer a.errMu.Unlock()
+
+	if a.err == nil {
+		a.err = err
+	}
+}
+
+// takeErr returns the first write error since the last call
+func (a *asyncWriter) takeErr() error {
+	a.errMu.Lock()
+	defer a.errMu.Unlock()
+
+	err := a.err
+	a.err = nil
+	return err
+}
+
+// enqueue copies b to the queue, it blocks when the queue is full unless records are dropped.
+// Records are written synchronously after Close.
+func (a *asyncWriter) enqueue(b []byte) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.closed {
+		return a.write(b)
+	}
+
+	cb := getBuffer()
+	*cb = append((*cb)[:0], b...)
+	item := asyncItem{b: cb}
+
+	if !a.drop {
+		a.queue <- item
+		return nil
+	}
+
+	select {
+	case a.queue <- item:
+	default:
+		putBuffer(cb)
+		a.dropped.Add(1)
+	}
+
+	return nil
+}
+
+// flush waits until records queued before the call are written
+func (a *asyncWriter) flush() error {
+	a.mu.RLock()
+	if !a.closed {
+		flushed := make(chan struct{})
+		a.queue <- asyncItem{flushed: flushed}
+		a.mu.RUnlock()
+		<-flushed
+	} else {
+		a.mu.RUnlock()
+	}
+
+	return a.takeErr()
+}
+
+// close writes queued records and stops the goroutine
+func (a *asyncWriter) close() error {
+	a.mu.Lock()
+	if !a.closed {
+		a.closed = true
+		close(a.queue)
+	}
+	a.mu.Unlock()
+
+	<-a.done
+
+	return a.takeErr()
+}
@@ -0,0 +1,127 @@
+package humanslog
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func Test_Async(t *testing.T) {
+	testAsyncFlush(t)
+	testAsyncClose(t)
+	testAsyncDropOnFull(t)
+	testAsyncConcurrent(t)
+}
+
+func testAsyncFlush(t *testing.T) {
+	w := &MockWriter{}
+	h := NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, AsyncQueueSize: 16})
+	defer h.Close()
+
+	logger := slog.New(h)
+	logger.Info("first")
+	logger.With("a", 1).Info("second")
+
+	if err := h.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "[]  INFO  first\n[]  INFO  second a=1\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testAsyncClose(t *testing.T) {
+	w := &MockWriter{}
+	h := NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, AsyncQueueSize: 16, BufferSize: 4096})
+	logger := slog.New(h)
+
+	logger.Info("queued")
+
+	if err := h.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// written synchronously after Close
+	logger.Info("after close")
+
+	if err := h.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "[]  INFO  queued\n[]  INFO  after close\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+// blockingWriter blocks writes until release is closed
+type blockingWriter struct {
+	MockWriter
+	started chan struct{}
+	release chan struct{}
+	once    sync.Once
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	w.once.Do(func() { close(w.started) })
+	<-w.release
+	return w.MockWriter.Write(p)
+}
+
+func testAsyncDropOnFull(t *testing.T) {
+	w := &blockingWriter{started: make(chan struct{}), release: make(chan struct{})}
+	h := NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, AsyncQueueSize: 1, AsyncDropOnFull: true})
+	logger := slog.New(h)
+
+	logger.Info("in flight")
+	<-w.started
+
+	logger.Info("queued")
+	logger.Info("dropped")
+	logger.Info("dropped")
+
+	close(w.release)
+
+	if err := h.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the drops are reported right after the write that was in progress while they happened
+	expected := "[]  INFO  in flight\nhumanslog: dropped 2 records, the async queue was full\n[]  INFO  queued\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testAsyncConcurrent(t *testing.T) {
+	w := &MockWriter{}
+	h := NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, AsyncQueueSize: 4})
+	logger := slog.New(h)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				logger.Info("msg", "j", j)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := h.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := strings.Count(string(w.WrittenData), "\n"); n != 400 {
+		t.Errorf("Expected 400 records, got %d", n)
+	}
+}
@@ -2,6 +2,8 @@ package humanslog
 
 import (
 	"log/slog"
+	"slices"
+	"sort"
 )
 
 type attributes []slog.Attr
@@ -17,3 +19,96 @@ func (a attributes) Less(i, j int) bool {
 
 	return a[i].Key < a[j].Key
 }
+
+// mapStrings returns a copy of the attributes with string values (also inside groups) replaced by f
+func (a attributes) mapStrings(group []string, f func(group []string, key string, s string) string) attributes {
+	mapped := make(attributes, len(a))
+	for i, attr := range a {
+		switch attr.Value.Kind() {
+		case slog.KindGroup:
+			attr.Value = slog.GroupValue(attributes(attr.Value.Group()).mapStrings(append(group, attr.Key), f)...)
+		case slog.KindString:
+			attr.Value = slog.StringValue(f(group, attr.Key, attr.Value.String()))
+		case slog.KindAny:
+			if s, ok := attr.Value.Any().(string); ok {
+				attr.Value = slog.StringValue(f(group, attr.Key, s))
+			}
+		}
+
+		mapped[i] = attr
+	}
+
+	return mapped
+}
+
+// appendResolved appends a with its value resolved following the slog.Handler rules:
+// empty attributes are ignored, attributes of groups with an empty key are inlined and empty groups are ignored
+func (a attributes) appendResolved(attr slog.Attr) attributes {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return a
+	}
+
+	if attr.Value.Kind() != slog.KindGroup {
+		return append(a, attr)
+	}
+
+	var group attributes
+	for _, ga := range attr.Value.Group() {
+		group = group.appendResolved(ga)
+	}
+
+	if len(group) == 0 {
+		return a
+	}
+
+	if attr.Key == "" {
+		return append(a, group...)
+	}
+
+	return append(a, slog.Attr{Key: attr.Key, Value: slog.GroupValue(group...)})
+}
+
+// SortMode is the order of attributes, see Options.SortMode
+type SortMode int
+
+const (
+	// SortNone keeps the order of the call site, keys of KeyOrder still come first
+	SortNone SortMode = iota
+
+	// SortAlpha sorts attributes by key, groups last
+	SortAlpha
+
+	// SortPriority renders keys of KeyOrder first, in their order, and sorts the rest by key
+	SortPriority
+)
+
+// sortAttrs orders as by SortMode and KeyOrder
+func (h *Handler) sortAttrs(as attributes) attributes {
+	switch {
+	case h.opts.SortMode == SortAlpha:
+		sort.Sort(as)
+		return as
+	case h.opts.SortMode == SortNone && len(h.opts.KeyOrder) == 0:
+		return as
+	}
+
+	rank := func(a slog.Attr) int {
+		if i := slices.Index(h.opts.KeyOrder, a.Key); i >= 0 {
+			return i
+		}
+		return len(h.opts.KeyOrder)
+	}
+
+	sorted := slices.Clone(as)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ri, rj := rank(sorted[i]), rank(sorted[j])
+		if ri != rj {
+			return ri < rj
+		}
+
+		return ri == len(h.opts.KeyOrder) && h.opts.SortMode == SortPriority && sorted.Less(i, j)
+	})
+
+	return sorted
+}
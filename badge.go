@@ -0,0 +1,95 @@
+package humanslog
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// BadgeStyle is the look of level badges
+type BadgeStyle struct {
+	// Width the level text is padded to, e.g. 5 aligns "INFO" with "DEBUG", 0 doesn't pad
+	Width int
+
+	// Spaces on both sides of the level text, 0 uses 1, negative means none
+	Padding int
+
+	// Render the level text in the level color instead of black on a background in the level color
+	NoBackground bool
+
+	// Emphasis of the level text, e.g. humanslog.Bold
+	Emphasis Emphasis
+}
+
+// levelString returns the badge text of level, v is the level value returned by ReplaceAttr
+func (h *Handler) levelString(level slog.Level, v slog.Value) string {
+	// LevelStrings and CustomLevels apply unless ReplaceAttr changed the value
+	if lv, isLevel := v.Any().(slog.Level); !isLevel || lv != level {
+		return v.String()
+	}
+
+	if s, ok := h.opts.LevelStrings[level]; ok {
+		return s
+	}
+
+	if len(h.opts.CustomLevels) == 0 {
+		return v.String()
+	}
+
+	// Like slog.Level.String, levels between named levels are shown relative to the one below, e.g. "FATAL+1"
+	spec := h.levelSpec(level)
+	if level == spec.Level {
+		return spec.Name
+	}
+
+	return fmt.Sprintf("%s%+d", spec.Name, level-spec.Level)
+}
+
+// levelColor returns the color of records at level, custom levels without a color use the color of the built-in level below
+func (h *Handler) levelColor(level slog.Level) color {
+	if c := h.levelSpec(level).Color; c != UnknownColor {
+		return h.getColor(c)
+	}
+
+	switch {
+	case level < 0:
+		return h.getColor(h.opts.DebugColor)
+	case level < 4:
+		return h.getColor(h.opts.InfoColor)
+	case level < 8:
+		return h.getColor(h.opts.WarnColor)
+	default:
+		return h.getColor(h.opts.ErrorColor)
+	}
+}
+
+// appendLevelBadge appends the level badge styled by BadgeStyle, followed by a space
+func (h *Handler) appendLevelBadge(b []byte, ls string, c color) []byte {
+	style := h.opts.BadgeStyle
+
+	padding := " "
+	if style.Padding > 0 {
+		padding = strings.Repeat(" ", style.Padding)
+	} else if style.Padding < 0 {
+		padding = ""
+	}
+
+	if style.Emphasis != 0 {
+		b = h.appendCode(b, h.styleCode(nil, Style{Emphasis: style.Emphasis}))
+	}
+	if style.NoBackground {
+		b = h.appendCode(b, c.fg)
+	} else {
+		b = h.appendCode(b, c.bg)
+		b = h.appendCode(b, fgBlack)
+	}
+	b = append(b, padding...)
+	b = append(b, ls...)
+	if w := displayWidth(ls); w < style.Width {
+		b = append(b, strings.Repeat(" ", style.Width-w)...)
+	}
+	b = append(b, padding...)
+	b = h.appendCode(b, resetColor)
+
+	return append(b, ' ')
+}
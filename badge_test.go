@@ -0,0 +1,74 @@
+package humanslog
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+)
+
+func Test_Badge(t *testing.T) {
+	testLevelStrings(t)
+	testCustomLevelStrings(t)
+	testBadgeStyle(t)
+}
+
+func testLevelStrings(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{
+		TimeFormat:   "[]",
+		NoColor:      true,
+		LevelStrings: map[slog.Level]string{slog.LevelInfo: "I", slog.LevelError: "E"},
+	}))
+
+	logger.Info("msg")
+	logger.Warn("msg")
+	logger.Error("msg")
+
+	expected := "[]  I  msg\n" +
+		"[]  WARN  msg\n" +
+		"[]  E  msg\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testCustomLevelStrings(t *testing.T) {
+	const (
+		levelTrace = slog.Level(-8)
+		levelFatal = slog.Level(12)
+	)
+
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{
+		HandlerOptions: &slog.HandlerOptions{Level: levelTrace},
+		TimeFormat:     "[]",
+		LevelStrings:   map[slog.Level]string{levelTrace: "TRACE", levelFatal: "FATAL"},
+	}))
+
+	logger.Log(context.Background(), levelTrace, "msg")
+	logger.Log(context.Background(), levelFatal, "msg")
+
+	expected := "\x1b[2m[]\x1b[0m \x1b[44m\x1b[30m TRACE \x1b[0m msg\n" +
+		"\x1b[2m[]\x1b[0m \x1b[41m\x1b[30m FATAL \x1b[0m msg\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testBadgeStyle(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{
+		TimeFormat: "[]",
+		BadgeStyle: BadgeStyle{Width: 5, Padding: -1, NoBackground: true},
+	}))
+
+	logger.Info("msg")
+
+	expected := "\x1b[2m[]\x1b[0m \x1b[32mINFO \x1b[0m msg\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
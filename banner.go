@@ -0,0 +1,85 @@
+package humanslog
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+// bannerKey is the key of the attribute returned by AsBanner
+const bannerKey = "humanslog.banner"
+
+// Width of banners when WrapWidth is not set and the terminal width is unknown
+const defaultBannerWidth = 80
+
+// bannerTitle keeps the title on the line of the rule
+var bannerTitle = strings.NewReplacer("\r", `\r`, "\n", `\n`)
+
+type bannerMarker struct{}
+
+// AsBanner returns an attribute rendering its record as a separator rule spanning the line, with the message as the title,
+// e.g. logger.Info("request 42", humanslog.AsBanner()). Logfmt output renders the record as usual.
+func AsBanner() slog.Attr {
+	return slog.Any(bannerKey, bannerMarker{})
+}
+
+// Banner logs title as a separator rule at the Info level, helping to delimit e.g. requests in dev server output
+func Banner(logger *slog.Logger, title string) {
+	logger.LogAttrs(context.Background(), slog.LevelInfo, title, AsBanner())
+}
+
+func isBanner(a slog.Attr) bool {
+	if a.Key != bannerKey || a.Value.Kind() != slog.KindAny {
+		return false
+	}
+
+	_, ok := a.Value.Any().(bannerMarker)
+	return ok
+}
+
+func isBannerRecord(r slog.Record) bool {
+	found := false
+	r.Attrs(func(a slog.Attr) bool {
+		found = isBanner(a)
+		return !found
+	})
+
+	return found
+}
+
+// formatBanner renders r as "── title k=v ───…" in the level color, filling WrapWidth or the terminal
+func (h *Handler) formatBanner(b []byte, r *slog.Record) []byte {
+	start := len(b)
+	c := h.levelColor(r.Level)
+	rule := h.glyphs("─")
+
+	as := make(attributes, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		if _, ok := styleOf(a); !ok && !isProgress(a) && !isBanner(a) {
+			as = as.appendResolved(a)
+		}
+		return true
+	})
+	as = h.sortAttrs(h.truncateStrings(h.redactAttrs(h.maskSecrets(h.filterKeys(as, nil), nil))))
+
+	b = append(b, h.colorString([]byte(strings.Repeat(rule, 2)), c.fg)...)
+	if r.Message != "" || len(as) > 0 {
+		b = append(b, ' ')
+		b = append(b, h.colored([]byte(bannerTitle.Replace(h.expandTabs(r.Message))), boldColor, c.fg)...)
+		b = h.formatLogfmtAttrs(b, as, []string{}, c.fg)
+		b = append(b, ' ')
+	}
+
+	width := h.wrapWidth()
+	if width <= 0 {
+		width = int(h.out.width.Load())
+	}
+	if width <= 0 {
+		width = defaultBannerWidth
+	}
+	if n := width - visibleLen(b[start:]); n > 0 {
+		b = append(b, h.colorString([]byte(strings.Repeat(rule, n)), c.fg)...)
+	}
+
+	return append(b, '\n')
+}
@@ -0,0 +1,72 @@
+package humanslog
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_Banner(t *testing.T) {
+	testBanner(t)
+	testBannerAttrs(t)
+	testBannerColored(t)
+	testBannerLogfmt(t)
+}
+
+func testBanner(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, WrapWidth: 20}))
+
+	Banner(logger, "request 42")
+	logger.Info("msg")
+
+	expected := "── request 42 ──────\n[]  INFO  msg\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testBannerAttrs(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, ASCIIOnly: true}))
+
+	logger.Info("GET /users", AsBanner(), "id", 42)
+
+	expected := "-- GET /users id=42 " + strings.Repeat("-", 60) + "\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testBannerColored(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", WrapWidth: 10}))
+
+	Banner(logger, "req")
+
+	expected := "\x1b[32m──\x1b[0m \x1b[1m\x1b[32mreq\x1b[0m \x1b[32m───\x1b[0m\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testBannerLogfmt(t *testing.T) {
+	w := &MockWriter{}
+	h := NewHandler(w, &Options{Format: FormatLogfmt})
+
+	r := slog.NewRecord(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), slog.LevelInfo, "request 42", 0)
+	r.AddAttrs(AsBanner())
+	_ = h.Handle(context.Background(), r)
+
+	expected := "time=2024-01-02T03:04:05.000Z level=INFO msg=\"request 42\"\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
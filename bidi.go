@@ -0,0 +1,53 @@
+package humanslog
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	firstStrongIsolate    = "\u2068"
+	popDirectionalIsolate = "\u2069"
+)
+
+// hasBidiControl checks for directional formatting characters and right-to-left text,
+// which could visually reorder the rest of the log line
+func hasBidiControl(s string) bool {
+	for _, r := range s {
+		switch {
+		case r == '\u061c', r == '\u200e', r == '\u200f':
+			return true
+		case r >= '\u202a' && r <= '\u202e':
+			return true
+		case r >= '\u2066' && r <= '\u2069':
+			return true
+		case r >= 0x0590 && unicode.In(r, unicode.Hebrew, unicode.Arabic, unicode.Syriac, unicode.Thaana, unicode.Nko):
+			return true
+		}
+	}
+
+	return false
+}
+
+// isolateBidi wraps s in Unicode bidi isolation characters, so directional overrides in s end with it
+func (h *Handler) isolateBidi(s string) string {
+	if !h.opts.IsolateBidi || !hasBidiControl(s) {
+		return s
+	}
+
+	// Unbalanced isolates inside s would otherwise close ours early
+	s = strings.NewReplacer(firstStrongIsolate, "", popDirectionalIsolate, "").Replace(s)
+
+	return firstStrongIsolate + s + popDirectionalIsolate
+}
+
+// isolateBidiAttrs applies isolateBidi to all string values
+func (h *Handler) isolateBidiAttrs(as attributes) attributes {
+	if !h.opts.IsolateBidi {
+		return as
+	}
+
+	return as.mapStrings(nil, func(_ []string, _ string, s string) string {
+		return h.isolateBidi(s)
+	})
+}
@@ -0,0 +1,38 @@
+package humanslog
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+func Test_Bidi(t *testing.T) {
+	testIsolateBidi(t)
+	testIsolateBidiDisabled(t)
+}
+
+func testIsolateBidi(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, IsolateBidi: true}))
+
+	logger.Info("login \u202euser", slog.String("name", "abc\u202edef"), slog.String("plain", "ok"))
+
+	expected := "[]  INFO  \u2068login \u202euser\u2069 name=\u2068abc\u202edef\u2069 plain=ok\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testIsolateBidiDisabled(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true}))
+
+	logger.Info("msg", slog.String("name", "abc\u202edef"))
+
+	expected := "[]  INFO  msg name=abc\u202edef\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
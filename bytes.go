@@ -0,0 +1,66 @@
+package humanslog
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+// BytesFormat selects the rendering of []byte values
+type BytesFormat int
+
+const (
+	// BytesAuto renders UTF-8 text as a string and other bytes as hex
+	BytesAuto BytesFormat = iota
+
+	// BytesHex renders all bytes as hex
+	BytesHex
+
+	// BytesBase64 renders all bytes as standard base64
+	BytesBase64
+)
+
+// formatBytes renders b as text, cut off by MaxStringLength, or as hex or base64 of up to MaxSlicePrintSize bytes
+// followed by the length
+func (h *Handler) formatBytes(b []byte) []byte {
+	return h.formatBytesAs(b, h.opts.BytesFormat)
+}
+
+func (h *Handler) formatBytesAs(b []byte, f BytesFormat) []byte {
+	if f == BytesAuto && isText(b) {
+		return h.formatLogfmtValue([]byte(h.truncateString(string(b))), h.stringColor(nil))
+	}
+
+	n := min(printLimit(h.opts.MaxSlicePrintSize), len(b))
+
+	var enc string
+	if f == BytesBase64 {
+		enc = base64.StdEncoding.EncodeToString(b[:n])
+	} else {
+		enc = hex.EncodeToString(b[:n])
+	}
+
+	out := h.colorString([]byte(enc), h.numberColor())
+	if n < len(b) {
+		out = append(out, h.faintedText([]byte(h.glyphs("…")))...)
+	}
+
+	return append(out, h.faintedText([]byte(" ("+strconv.Itoa(len(b))+" bytes)"))...)
+}
+
+// isText reports whether b is UTF-8 without control characters other than tabs and newlines
+func isText(b []byte) bool {
+	if !utf8.Valid(b) {
+		return false
+	}
+
+	for _, r := range string(b) {
+		if unicode.IsControl(r) && r != '\n' && r != '\r' && r != '\t' {
+			return false
+		}
+	}
+
+	return true
+}
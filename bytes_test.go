@@ -0,0 +1,68 @@
+package humanslog
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+func Test_Bytes(t *testing.T) {
+	testBytesAuto(t)
+	testBytesInStruct(t)
+	testBytesBase64(t)
+	testBytesTruncated(t)
+}
+
+func testBytesAuto(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, MaxStringLength: 3}))
+
+	logger.Info("msg", "text", []byte("hello"), "bin", []byte{0xff, 0, 1})
+
+	expected := "[]  INFO  msg text=hel…(+2 bytes) bin=ff0001 (3 bytes)\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testBytesInStruct(t *testing.T) {
+	type packet struct {
+		Payload []byte
+	}
+
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true}))
+
+	logger.Info("msg", "p", packet{Payload: []byte{0xca, 0xfe}})
+
+	if !bytes.Contains(w.WrittenData, []byte("Payload: cafe (2 bytes)")) {
+		t.Errorf("Expected the payload as hex, got %q", w.WrittenData)
+	}
+}
+
+func testBytesBase64(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, BytesFormat: BytesBase64}))
+
+	logger.Info("msg", "text", []byte("hello"))
+
+	expected := "[]  INFO  msg text=aGVsbG8= (5 bytes)\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testBytesTruncated(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, BytesFormat: BytesHex, MaxSlicePrintSize: 2}))
+
+	logger.Info("msg", "bin", []byte{1, 2, 3, 4})
+
+	expected := "[]  INFO  msg bin=0102… (4 bytes)\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
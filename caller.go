@@ -0,0 +1,54 @@
+package humanslog
+
+import (
+	"context"
+	"runtime"
+	"slices"
+)
+
+type callerSkipKey struct{}
+
+// WithCallerSkip returns a copy of ctx which makes the handler report the source n frames above the logging call,
+// for logging facades which call slog on behalf of their callers. It adds to Options.CallerSkip.
+func WithCallerSkip(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, callerSkipKey{}, callerSkip(ctx)+n)
+}
+
+func callerSkip(ctx context.Context) int {
+	if ctx == nil {
+		return 0
+	}
+
+	n, _ := ctx.Value(callerSkipKey{}).(int)
+	return n
+}
+
+// callerPC returns the PC of the frame skipped frames above pc in the current call stack,
+// pc is returned as is when it isn't found
+func (h *Handler) callerPC(ctx context.Context, pc uintptr) uintptr {
+	skip := h.opts.CallerSkip + callerSkip(ctx)
+	if skip <= 0 || pc == 0 {
+		return pc
+	}
+
+	var pcs [64]uintptr
+	n := runtime.Callers(2, pcs[:])
+	i := slices.Index(pcs[:n], pc)
+	if i < 0 {
+		return pc
+	}
+
+	// Frames are walked instead of PCs, the facade may be inlined into its caller
+	frames := runtime.CallersFrames(pcs[i:n])
+	for {
+		f, more := frames.Next()
+		if skip == 0 {
+			// Frame.PC points at the call instruction, the source location looks up the PC before the return address
+			return f.PC + 1
+		}
+		if !more {
+			return pc
+		}
+		skip--
+	}
+}
@@ -0,0 +1,67 @@
+package humanslog
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"testing"
+)
+
+func Test_CallerSkip(t *testing.T) {
+	testCallerSkip(t)
+	testCallerSkipInlined(t)
+	testWithCallerSkip(t)
+}
+
+//go:noinline
+func logThroughFacade(ctx context.Context, logger *slog.Logger, msg string) {
+	logger.InfoContext(ctx, msg)
+}
+
+func logThroughInlinedFacade(logger *slog.Logger, msg string) {
+	logger.Info(msg)
+}
+
+func testCallerSkip(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{HandlerOptions: &slog.HandlerOptions{AddSource: true}, TimeFormat: "[]", NoColor: true, SourcePath: SourcePathRelative, CallerSkip: 1}))
+
+	_, _, line, _ := runtime.Caller(0)
+	logThroughFacade(context.Background(), logger, "msg")
+
+	expected := fmt.Sprintf("[] caller_test.go:%d  INFO  msg\n", line+1)
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testCallerSkipInlined(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{HandlerOptions: &slog.HandlerOptions{AddSource: true}, TimeFormat: "[]", NoColor: true, SourcePath: SourcePathRelative, CallerSkip: 1}))
+
+	_, _, line, _ := runtime.Caller(0)
+	logThroughInlinedFacade(logger, "msg")
+
+	expected := fmt.Sprintf("[] caller_test.go:%d  INFO  msg\n", line+1)
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testWithCallerSkip(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{HandlerOptions: &slog.HandlerOptions{AddSource: true}, TimeFormat: "[]", NoColor: true, SourcePath: SourcePathRelative}))
+
+	_, _, line, _ := runtime.Caller(0)
+	logThroughFacade(WithCallerSkip(context.Background(), 1), logger, "msg")
+	logThroughFacade(context.Background(), logger, "msg")
+
+	expected := fmt.Sprintf("[] caller_test.go:%d  INFO  msg\n[] caller_test.go:19  INFO  msg\n", line+1)
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
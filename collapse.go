@@ -0,0 +1,35 @@
+package humanslog
+
+import (
+	"log/slog"
+	"strconv"
+)
+
+// collapsedGroup replaces the value of a group with more than CollapseGroupsOver attributes, it's the number of them
+type collapsedGroup int
+
+// collapseGroups replaces groups with more than CollapseGroupsOver attributes by their size, unless the level is Debug
+func (h *Handler) collapseGroups(as attributes) attributes {
+	if h.opts.CollapseGroupsOver <= 0 || h.leveler().Level() <= slog.LevelDebug {
+		return as
+	}
+
+	collapsed := make(attributes, len(as))
+	for i, a := range as {
+		if a.Value.Kind() == slog.KindGroup {
+			if ga := a.Value.Group(); len(ga) > h.opts.CollapseGroupsOver {
+				a.Value = slog.AnyValue(collapsedGroup(len(ga)))
+			} else {
+				a.Value = slog.GroupValue(h.collapseGroups(ga)...)
+			}
+		}
+
+		collapsed[i] = a
+	}
+
+	return collapsed
+}
+
+func (h *Handler) formatCollapsedGroup(n collapsedGroup) []byte {
+	return h.faintedText([]byte(h.glyphs("{… " + strconv.Itoa(int(n)) + " attrs}")))
+}
@@ -0,0 +1,52 @@
+package humanslog
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+func Test_CollapseGroups(t *testing.T) {
+	testCollapseGroups(t)
+	testCollapseGroupsNested(t)
+	testCollapseGroupsDebug(t)
+}
+
+func testCollapseGroups(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, CollapseGroupsOver: 2}))
+
+	logger.Info("request", slog.Group("meta", "a", 1, "b", 2, "c", 3), slog.Group("user", "id", 7))
+
+	expected := "[]  INFO  request meta={… 3 attrs} user.id=7\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testCollapseGroupsNested(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, CollapseGroupsOver: 2}))
+
+	logger.Info("request", slog.Group("http", "method", "GET", slog.Group("headers", "a", 1, "b", 2, "c", 3)))
+
+	expected := "[]  INFO  request http.method=GET http.headers={… 3 attrs}\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testCollapseGroupsDebug(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{HandlerOptions: &slog.HandlerOptions{Level: slog.LevelDebug}, TimeFormat: "[]", NoColor: true, CollapseGroupsOver: 2}))
+
+	logger.Info("request", slog.Group("meta", "a", 1, "b", 2, "c", 3))
+
+	expected := "[]  INFO  request meta.a=1 meta.b=2 meta.c=3\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
@@ -32,9 +32,11 @@ var (
 	bgMagenta backgroundColor = []byte("\x1b[45m")
 	bgCyan    backgroundColor = []byte("\x1b[46m")
 	bgWhite   backgroundColor = []byte("\x1b[47m")
+	bgGray    backgroundColor = []byte("\x1b[100m")
 
 	// Common consts
 	resetColor     commonValuesColor = []byte("\x1b[0m")
+	boldColor      commonValuesColor = []byte("\x1b[1m")
 	faintColor     commonValuesColor = []byte("\x1b[2m")
 	underlineColor commonValuesColor = []byte("\x1b[4m")
 )
@@ -51,6 +53,7 @@ const (
 	Magenta
 	Cyan
 	White
+	Gray
 )
 
 var colors = []color{
@@ -63,9 +66,10 @@ var colors = []color{
 	{fgMagenta, bgMagenta},
 	{fgCyan, bgCyan},
 	{fgWhite, bgWhite},
+	{fgGray, bgGray},
 }
 
-func (h *developHandler) getColor(c Color) color {
+func (h *Handler) getColor(c Color) color {
 	if int(c) < len(colors) {
 		return colors[c]
 	}
@@ -73,59 +77,205 @@ func (h *developHandler) getColor(c Color) color {
 	return colors[White]
 }
 
-// Color string foreground
-func (h *developHandler) colorString(b []byte, fgColor foregroundColor) []byte {
-	if h.opts.NoColor {
-		return b
+// Colors of output elements, UnknownColor keeps the default of the element, see also Styles
+type Colors struct {
+	// Attribute keys, also of JSON, YAML and XML, default: humanslog.Gray
+	Key Color
+
+	// Numbers, default: humanslog.Cyan
+	Number Color
+
+	// String values, default: uncolored, humanslog.White in JSON and YAML
+	String Color
+
+	// URLs, default: humanslog.Cyan
+	URL Color
+
+	// Type names, default: humanslog.Yellow
+	Type Color
+
+	// Braces, brackets, colons and commas of structs, JSON, YAML and XML, default: depends on the value
+	Punct Color
+
+	// Timestamps, default: faint
+	Time Color
+
+	// Source info, default: humanslog.White
+	Source Color
+}
+
+// elementColor returns the escape codes of an output element: the emphasis and background of s with the color of s,
+// c of Colors or def, in this order, UnknownColor is skipped
+func (h *Handler) elementColor(s Style, c Color, def foregroundColor) foregroundColor {
+	fg := def
+	if s.Color != UnknownColor && validColor(s.Color) {
+		fg = h.getColor(s.Color).fg
+	} else if c != UnknownColor && validColor(c) {
+		fg = h.getColor(c).fg
+	}
+
+	if s.Emphasis == 0 && s.Background == UnknownColor {
+		return fg
 	}
 
-	b = append(fgColor, b...)
-	b = append(b, resetColor...)
-	return b
+	code := h.styleCode(nil, Style{Background: s.Background, Emphasis: s.Emphasis})
+	return append(code, fg...)
 }
 
-// Color string fainted
-func (h *developHandler) colorStringFainted(b []byte, fgColor foregroundColor) []byte {
+func (h *Handler) keyColor() foregroundColor {
+	return h.elementColor(h.opts.Styles.Key, h.opts.Colors.Key, fgGray)
+}
+
+func (h *Handler) numberColor() foregroundColor {
+	return h.elementColor(h.opts.Styles.Number, h.opts.Colors.Number, fgCyan)
+}
+
+func (h *Handler) urlColor() foregroundColor {
+	return h.elementColor(h.opts.Styles.URL, h.opts.Colors.URL, fgCyan)
+}
+
+func (h *Handler) typeColor() foregroundColor {
+	return h.elementColor(h.opts.Styles.Type, h.opts.Colors.Type, fgYellow)
+}
+
+func (h *Handler) sourceColor() foregroundColor {
+	return h.elementColor(h.opts.Styles.Source, h.opts.Colors.Source, fgWhite)
+}
+
+// stringColor returns the style of strings, def where strings are colored by default
+func (h *Handler) stringColor(def foregroundColor) foregroundColor {
+	return h.elementColor(h.opts.Styles.String, h.opts.Colors.String, def)
+}
+
+// punctColor returns the style of punctuation, def of the punctuation mark by default
+func (h *Handler) punctColor(def foregroundColor) foregroundColor {
+	return h.elementColor(h.opts.Styles.Punct, h.opts.Colors.Punct, def)
+}
+
+// timeCode returns the escape codes of timestamps, faint unless Colors.Time or Styles.Time.Color is set
+func (h *Handler) timeCode() []byte {
+	return h.elementColor(h.opts.Styles.Time, h.opts.Colors.Time, foregroundColor(faintColor))
+}
+
+// appendCode appends an escape code unless colors are disabled
+func (h *Handler) appendCode(b []byte, code []byte) []byte {
 	if h.opts.NoColor {
 		return b
 	}
 
-	b = append(fgColor, b...)
-	b = append(faintColor, b...)
-	b = append(b, resetColor...)
-	return b
+	return append(b, code...)
 }
 
-// Color string background
-func (h *developHandler) colorStringBackgorund(b []byte, fgColor foregroundColor, bgColor backgroundColor) []byte {
+// appendColored appends s wrapped in the escape codes, codes are applied in order
+func (h *Handler) appendColored(b []byte, s []byte, codes ...[]byte) []byte {
 	if h.opts.NoColor {
-		return b
+		return append(b, s...)
 	}
 
-	b = append(fgColor, b...)
-	b = append(bgColor, b...)
-	b = append(b, resetColor...)
-	return b
+	for _, c := range codes {
+		b = append(b, c...)
+	}
+	b = append(b, s...)
+	return append(b, resetColor...)
 }
 
-// Underline text
-func (h *developHandler) underlineText(b []byte) []byte {
+// colored returns a new slice with b wrapped in the escape codes, b and codes are never modified
+func (h *Handler) colored(b []byte, codes ...[]byte) []byte {
 	if h.opts.NoColor {
 		return b
 	}
 
-	b = append(underlineColor, b...)
-	b = append(b, resetColor...)
-	return b
+	n := len(b) + len(resetColor)
+	for _, c := range codes {
+		n += len(c)
+	}
+
+	return h.appendColored(make([]byte, 0, n), b, codes...)
+}
+
+// Color string foreground
+func (h *Handler) colorString(b []byte, fgColor foregroundColor) []byte {
+	return h.colored(b, fgColor)
+}
+
+// Color string fainted
+func (h *Handler) colorStringFainted(b []byte, fgColor foregroundColor) []byte {
+	return h.colored(b, faintColor, fgColor)
+}
+
+// Color string background
+func (h *Handler) colorStringBackgorund(b []byte, fgColor foregroundColor, bgColor backgroundColor) []byte {
+	return h.colored(b, bgColor, fgColor)
+}
+
+// Underline text
+func (h *Handler) underlineText(b []byte) []byte {
+	return h.colored(b, underlineColor)
 }
 
 // Fainted text
-func (h *developHandler) faintedText(b []byte) []byte {
-	if h.opts.NoColor {
-		return b
+func (h *Handler) faintedText(b []byte) []byte {
+	return h.colored(b, faintColor)
+}
+
+// escapeLen returns the length of the CSI ("\x1b[...m") or OSC ("\x1b]...\x1b\\") sequence at b[i:], 0 when there is none
+func escapeLen(b []byte, i int) int {
+	if b[i] != '\x1b' || i+1 >= len(b) {
+		return 0
+	}
+
+	switch b[i+1] {
+	case '[':
+		j := i + 2
+		for j < len(b) && (b[j] < 0x40 || b[j] > 0x7e) {
+			j++
+		}
+		return min(j+1, len(b)) - i
+	case ']':
+		for j := i + 2; j < len(b); j++ {
+			if b[j] == '\a' {
+				return j + 1 - i
+			}
+			if b[j] == '\x1b' && j+1 < len(b) && b[j+1] == '\\' {
+				return j + 2 - i
+			}
+		}
+		return len(b) - i
+	default:
+		return 0
+	}
+}
+
+// visibleLen returns the display width of b without ANSI escape sequences
+func visibleLen(b []byte) int {
+	n := 0
+	start := 0
+	for i := 0; i < len(b); i++ {
+		if l := escapeLen(b, i); l > 0 {
+			n += displayWidthBytes(b[start:i])
+			i += l - 1
+			start = i + 1
+		}
+	}
+
+	if start < len(b) {
+		n += displayWidthBytes(b[start:])
+	}
+
+	return n
+}
+
+// stripANSI returns b without ANSI escape sequences
+func stripANSI(b []byte) []byte {
+	out := make([]byte, 0, len(b))
+	for i := 0; i < len(b); i++ {
+		if l := escapeLen(b, i); l > 0 {
+			i += l - 1
+			continue
+		}
+
+		out = append(out, b[i])
 	}
 
-	b = append(faintColor, b...)
-	b = append(b, resetColor...)
-	return b
+	return out
 }
@@ -15,9 +15,10 @@ func Test_Color(t *testing.T) {
 	testColorColorStringBackground(t, b, h)
 	testColorUnderlineText(t, b, h)
 	testColorFaintedText(t, b, h)
+	testVisibleLen(t)
 }
 
-func testGetColor(t *testing.T, h *developHandler) {
+func testGetColor(t *testing.T, h *Handler) {
 	result := h.getColor(Black)
 	expected := colors[1].fg
 
@@ -33,7 +34,7 @@ func testGetColor(t *testing.T, h *developHandler) {
 	}
 }
 
-func testColorColorString(t *testing.T, b []byte, h *developHandler) {
+func testColorColorString(t *testing.T, b []byte, h *Handler) {
 	result := h.colorString(b, fgGreen)
 
 	expected := []byte("\x1b[32mHello\x1b[0m")
@@ -42,7 +43,7 @@ func testColorColorString(t *testing.T, b []byte, h *developHandler) {
 	}
 }
 
-func testColorColorStringFainted(t *testing.T, b []byte, h *developHandler) {
+func testColorColorStringFainted(t *testing.T, b []byte, h *Handler) {
 	result := h.colorStringFainted(b, fgBlue)
 
 	expected := []byte("\x1b[2m\x1b[34mHello\x1b[0m")
@@ -51,7 +52,7 @@ func testColorColorStringFainted(t *testing.T, b []byte, h *developHandler) {
 	}
 }
 
-func testColorColorStringBackground(t *testing.T, b []byte, h *developHandler) {
+func testColorColorStringBackground(t *testing.T, b []byte, h *Handler) {
 	result := h.colorStringBackgorund(b, fgYellow, bgRed)
 
 	expected := []byte("\x1b[41m\x1b[33mHello\x1b[0m")
@@ -60,7 +61,7 @@ func testColorColorStringBackground(t *testing.T, b []byte, h *developHandler) {
 	}
 }
 
-func testColorUnderlineText(t *testing.T, b []byte, h *developHandler) {
+func testColorUnderlineText(t *testing.T, b []byte, h *Handler) {
 	result := h.underlineText(b)
 
 	expected := []byte("\x1b[4mHello\x1b[0m")
@@ -69,7 +70,7 @@ func testColorUnderlineText(t *testing.T, b []byte, h *developHandler) {
 	}
 }
 
-func testColorFaintedText(t *testing.T, b []byte, h *developHandler) {
+func testColorFaintedText(t *testing.T, b []byte, h *Handler) {
 	result := h.faintedText(b)
 
 	expected := []byte("\x1b[2mHello\x1b[0m")
@@ -77,3 +78,11 @@ func testColorFaintedText(t *testing.T, b []byte, h *developHandler) {
 		t.Errorf("\nExpected: %s\nResult:   %s\nExpected: %[1]q\nResult:   %[2]q", expected, result)
 	}
 }
+
+func testVisibleLen(t *testing.T) {
+	b := []byte("\x1b[2m\x1b[34mHello\x1b[0m world")
+
+	if l := visibleLen(b); l != 11 {
+		t.Errorf("Expected visible length 11, got %d", l)
+	}
+}
@@ -0,0 +1,54 @@
+package humanslog
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+func Test_Colors(t *testing.T) {
+	testColorsInline(t)
+	testColorsJSON(t)
+	testColorsDefault(t)
+}
+
+func testColorsInline(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{
+		TimeFormat: "[]",
+		Colors:     Colors{Key: Blue, Number: Magenta, String: Yellow, URL: Green, Time: White},
+	}))
+
+	logger.Info("msg", "n", 1, "s", "text", "u", "https://example.com")
+
+	expected := "\x1b[37m[]\x1b[0m \x1b[42m\x1b[30m INFO \x1b[0m msg \x1b[34mn=\x1b[0m\x1b[35m1\x1b[0m \x1b[34ms=\x1b[0m\x1b[33mtext\x1b[0m " +
+		"\x1b[34mu=\x1b[0m\x1b[32mhttps://example.com\x1b[0m\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testColorsJSON(t *testing.T) {
+	h := NewHandler(&MockWriter{}, &Options{Colors: Colors{Key: Blue, Number: Magenta, String: Yellow, Punct: Red}})
+
+	got := string(h.colorizeJSONBytes([]byte(`{"a":1}`), false, 0))
+	expected := "\x1b[31m{\x1b[0m\x1b[34m\"\x1b[0m\x1b[34ma\x1b[0m\x1b[34m\"\x1b[0m\x1b[31m:\x1b[0m\x1b[35m1\x1b[0m\x1b[31m}\x1b[0m"
+
+	if got != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, got)
+	}
+}
+
+func testColorsDefault(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]"}))
+
+	logger.Info("msg", "n", 1, "s", "text")
+
+	expected := "\x1b[2m[]\x1b[0m \x1b[42m\x1b[30m INFO \x1b[0m msg \x1b[90mn=\x1b[0m\x1b[36m1\x1b[0m \x1b[90ms=\x1b[0mtext\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
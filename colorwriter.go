@@ -0,0 +1,80 @@
+package humanslog
+
+import (
+	"errors"
+	"io"
+)
+
+// ColorWriter is a writer deciding itself whether colors reach its output.
+// The handler renders colors for a ColorWriter reporting Colored, regardless of terminal detection,
+// and plain text otherwise.
+type ColorWriter interface {
+	io.Writer
+
+	// Colored reports whether ANSI escape sequences written to the writer are kept
+	Colored() bool
+}
+
+type colorWriter struct {
+	w       io.Writer
+	colored bool
+}
+
+// NewColorWriter returns a ColorWriter writing to w, ANSI escape sequences are stripped unless colored is true
+// and w supports them (legacy Windows consoles don't)
+func NewColorWriter(w io.Writer, colored bool) ColorWriter {
+	return &colorWriter{w: w, colored: colored && enableColors(w)}
+}
+
+func (cw *colorWriter) Colored() bool {
+	return cw.colored
+}
+
+func (cw *colorWriter) Write(p []byte) (int, error) {
+	if cw.colored {
+		return cw.w.Write(p)
+	}
+
+	if _, err := cw.w.Write(stripANSI(p)); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+type multiColorWriter struct {
+	writers []ColorWriter
+}
+
+// NewMultiColorWriter returns a ColorWriter writing to all writers, it's colored when any of them is,
+// e.g. colored output to stderr and plain text to a file:
+//
+//	humanslog.NewMultiColorWriter(humanslog.NewColorWriter(os.Stderr, true), humanslog.NewColorWriter(f, false))
+func NewMultiColorWriter(writers ...ColorWriter) ColorWriter {
+	return &multiColorWriter{writers: writers}
+}
+
+func (mw *multiColorWriter) Colored() bool {
+	for _, w := range mw.writers {
+		if w.Colored() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (mw *multiColorWriter) Write(p []byte) (int, error) {
+	var errs []error
+	for _, w := range mw.writers {
+		if _, err := w.Write(p); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,42 @@
+package humanslog
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func Test_ColorWriter(t *testing.T) {
+	testColorWriterStrips(t)
+	testMultiColorWriter(t)
+}
+
+func testColorWriterStrips(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(NewColorWriter(w, false), &Options{TimeFormat: "[]"}))
+
+	logger.Info("msg", slog.Int("n", 1))
+
+	expected := "[]  INFO  msg n=1\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testMultiColorWriter(t *testing.T) {
+	colored := &MockWriter{}
+	plain := &MockWriter{}
+	logger := slog.New(NewHandler(NewMultiColorWriter(NewColorWriter(colored, true), NewColorWriter(plain, false)), &Options{TimeFormat: "[]", AutoDetectTTY: true}))
+
+	logger.Info("msg", slog.Int("n", 1))
+
+	expectedColored := "\x1b[2m[]\x1b[0m \x1b[42m\x1b[30m INFO \x1b[0m msg \x1b[90mn=\x1b[0m\x1b[36m1\x1b[0m\n"
+	expectedPlain := "[]  INFO  msg n=1\n"
+
+	if string(colored.WrittenData) != expectedColored {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expectedColored, colored.WrittenData)
+	}
+	if string(plain.WrittenData) != expectedPlain {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expectedPlain, plain.WrittenData)
+	}
+}
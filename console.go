@@ -0,0 +1,186 @@
+package humanslog
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+)
+
+// Windows console character attributes
+const (
+	consoleForegroundBlue      uint16 = 0x0001
+	consoleForegroundGreen     uint16 = 0x0002
+	consoleForegroundRed       uint16 = 0x0004
+	consoleForegroundIntensity uint16 = 0x0008
+	consoleBackgroundBlue      uint16 = 0x0010
+	consoleBackgroundGreen     uint16 = 0x0020
+	consoleBackgroundRed       uint16 = 0x0040
+	consoleUnderscore          uint16 = 0x8000
+
+	consoleForegroundMask = consoleForegroundBlue | consoleForegroundGreen | consoleForegroundRed | consoleForegroundIntensity
+	consoleBackgroundMask = consoleBackgroundBlue | consoleBackgroundGreen | consoleBackgroundRed
+)
+
+// consoleWriter translates ANSI SGR sequences written by the handler into console attributes,
+// for terminals which can't process VT sequences (legacy cmd.exe, old ConEmu)
+type consoleWriter struct {
+	w           io.Writer
+	setAttr     func(attr uint16) error
+	defaultAttr uint16
+	attr        uint16
+	pending     []byte
+
+	// control moves the cursor up n lines for 'A' and erases from the cursor to the end of the screen
+	// for 'J' or of the line for 'K', the sequences are dropped when it's nil
+	control func(cmd byte, n int) error
+}
+
+func newConsoleWriter(w io.Writer, defaultAttr uint16, setAttr func(attr uint16) error, control func(cmd byte, n int) error) *consoleWriter {
+	return &consoleWriter{
+		w:           w,
+		setAttr:     setAttr,
+		control:     control,
+		defaultAttr: defaultAttr,
+		attr:        defaultAttr,
+	}
+}
+
+func (cw *consoleWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	if len(cw.pending) > 0 {
+		p = append(cw.pending, p...)
+		cw.pending = nil
+	}
+
+	for len(p) > 0 {
+		i := bytes.IndexByte(p, '\x1b')
+		if i < 0 {
+			break
+		}
+
+		if err := cw.writeText(p[:i]); err != nil {
+			return 0, err
+		}
+
+		size, err := cw.applySequence(p[i:])
+		if err != nil {
+			return 0, err
+		}
+		if size == 0 {
+			// Sequence continues in the next write
+			cw.pending = append(cw.pending, p[i:]...)
+			return n, nil
+		}
+
+		p = p[i+size:]
+	}
+
+	if err := cw.writeText(p); err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+// applySequence applies the escape sequence at the start of p and returns its length, 0 when it's incomplete.
+// OSC sequences, e.g. hyperlinks, are dropped and only their text is written.
+func (cw *consoleWriter) applySequence(p []byte) (int, error) {
+	if len(p) < 2 {
+		return 0, nil
+	}
+
+	switch p[1] {
+	case '[':
+		end := bytes.IndexFunc(p[2:], func(r rune) bool {
+			return (r < '0' || r > '9') && r != ';'
+		})
+		if end < 0 {
+			return 0, nil
+		}
+
+		end += 2
+		params := p[2:end]
+		switch cmd := p[end]; cmd {
+		case 'm':
+			return end + 1, cw.applySGR(params)
+		case 'A', 'J', 'K':
+			if cw.control == nil {
+				return end + 1, nil
+			}
+
+			n, err := strconv.Atoi(string(params))
+			if err != nil && cmd == 'A' {
+				n = 1
+			}
+
+			return end + 1, cw.control(cmd, n)
+		}
+
+		return end + 1, nil
+	case ']':
+		end := bytes.Index(p, []byte("\x1b\\"))
+		if end < 0 {
+			return 0, nil
+		}
+
+		return end + 2, nil
+	}
+
+	// not a sequence the handler writes, the escape is passed through
+	return 1, cw.writeText(p[:1])
+}
+
+func (cw *consoleWriter) writeText(p []byte) error {
+	if len(p) == 0 {
+		return nil
+	}
+
+	_, err := cw.w.Write(p)
+	return err
+}
+
+// applySGR sets console attributes for parameters of one "\x1b[...m" sequence
+func (cw *consoleWriter) applySGR(params []byte) error {
+	attr := cw.attr
+	for _, p := range bytes.Split(params, []byte(";")) {
+		code, err := strconv.Atoi(string(p))
+		if err != nil {
+			code = 0
+		}
+
+		switch {
+		case code == 0:
+			attr = cw.defaultAttr
+		case code == 1:
+			attr |= consoleForegroundIntensity
+		case code == 2 || code == 22:
+			attr &^= consoleForegroundIntensity
+		case code == 4:
+			attr |= consoleUnderscore
+		case code == 24:
+			attr &^= consoleUnderscore
+		case code >= 30 && code <= 37:
+			attr = attr&^consoleForegroundMask | ansiToConsoleColor(code-30)
+		case code == 39:
+			attr = attr&^consoleForegroundMask | cw.defaultAttr&consoleForegroundMask
+		case code >= 40 && code <= 47:
+			attr = attr&^consoleBackgroundMask | ansiToConsoleColor(code-40)<<4
+		case code == 49:
+			attr = attr&^consoleBackgroundMask | cw.defaultAttr&consoleBackgroundMask
+		case code >= 90 && code <= 97:
+			attr = attr&^consoleForegroundMask | ansiToConsoleColor(code-90) | consoleForegroundIntensity
+		}
+	}
+
+	if attr == cw.attr {
+		return nil
+	}
+
+	cw.attr = attr
+	return cw.setAttr(attr)
+}
+
+// ansiToConsoleColor converts ANSI color index (red=1, blue=4) to console bits (red=4, blue=1)
+func ansiToConsoleColor(c int) uint16 {
+	return uint16(c&1)<<2 | uint16(c&2) | uint16(c&4)>>2
+}
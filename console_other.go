@@ -0,0 +1,20 @@
+//go:build !windows
+
+package humanslog
+
+import (
+	"io"
+	"os"
+)
+
+// NewConsoleWriter returns a writer translating the handler's ANSI colors into SetConsoleTextAttribute calls.
+// Use it for legacy Windows consoles where VT processing can't be enabled.
+// On other platforms terminals handle ANSI colors, so f is returned unchanged.
+func NewConsoleWriter(f *os.File) io.Writer {
+	return f
+}
+
+// enableColors reports whether out can render ANSI colors, which needs no setup outside of Windows
+func enableColors(out io.Writer) bool {
+	return true
+}
@@ -0,0 +1,129 @@
+package humanslog
+
+import (
+	"bytes"
+	"log/slog"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func Test_Console(t *testing.T) {
+	testConsoleWriterColors(t)
+	testConsoleWriterSplitSequence(t)
+	testConsoleWriterHandler(t)
+	testConsoleWriterControl(t)
+	testEnableColorsWriter(t)
+}
+
+func testEnableColorsWriter(t *testing.T) {
+	// writers other than files, e.g. NewConsoleWriter, keep colors
+	w := &MockWriter{}
+	if !enableColors(w) {
+		t.Errorf("Expected colors to be enabled for %T", w)
+	}
+
+	h := NewHandler(w, nil)
+	if h.opts.NoColor {
+		t.Errorf("Expected NoColor to stay disabled")
+	}
+}
+
+const consoleDefault = consoleForegroundRed | consoleForegroundGreen | consoleForegroundBlue
+
+func newTestConsoleWriter(w *MockWriter) (*consoleWriter, *[]uint16) {
+	attrs := &[]uint16{}
+	cw := newConsoleWriter(w, consoleDefault, func(attr uint16) error {
+		*attrs = append(*attrs, attr)
+		return nil
+	}, nil)
+
+	return cw, attrs
+}
+
+func testConsoleWriterColors(t *testing.T) {
+	w := &MockWriter{}
+	cw, attrs := newTestConsoleWriter(w)
+
+	_, err := cw.Write([]byte("a\x1b[31mred\x1b[0m \x1b[42m\x1b[30mbadge\x1b[0m \x1b[90mgray\x1b[0m"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(w.WrittenData) != "ared badge gray" {
+		t.Errorf("Expected ANSI sequences to be stripped, got %q", w.WrittenData)
+	}
+
+	expected := []uint16{
+		consoleForegroundRed,
+		consoleDefault,
+		consoleDefault | consoleBackgroundGreen,
+		consoleBackgroundGreen,
+		consoleDefault,
+		consoleForegroundIntensity,
+		consoleDefault,
+	}
+
+	if len(*attrs) != len(expected) {
+		t.Fatalf("Expected attributes %v, got %v", expected, *attrs)
+	}
+
+	for i := range expected {
+		if (*attrs)[i] != expected[i] {
+			t.Errorf("Expected attributes %v, got %v", expected, *attrs)
+			break
+		}
+	}
+}
+
+func testConsoleWriterSplitSequence(t *testing.T) {
+	w := &MockWriter{}
+	cw, attrs := newTestConsoleWriter(w)
+
+	cw.Write([]byte("x\x1b[3"))
+	cw.Write([]byte("6my"))
+
+	if string(w.WrittenData) != "xy" {
+		t.Errorf("Expected split sequence to be stripped, got %q", w.WrittenData)
+	}
+
+	if len(*attrs) != 1 || (*attrs)[0] != consoleForegroundGreen|consoleForegroundBlue {
+		t.Errorf("Expected cyan attribute, got %v", *attrs)
+	}
+}
+
+func testConsoleWriterHandler(t *testing.T) {
+	w := &MockWriter{}
+	cw, _ := newTestConsoleWriter(w)
+
+	slog.New(NewHandler(cw, &Options{TimeFormat: "[]"})).Info("msg", "i", 1)
+
+	expected := "[]  INFO  msg i=1\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testConsoleWriterControl(t *testing.T) {
+	w := &MockWriter{}
+
+	var controls []string
+	cw := newConsoleWriter(w, consoleDefault, func(uint16) error { return nil }, func(cmd byte, n int) error {
+		controls = append(controls, string(cmd)+strconv.Itoa(n))
+		return nil
+	})
+
+	cw.Write([]byte("a\x1b[2A\r\x1b[Jb \x1b]8;;file:///x.go\x1b\\x.go\x1b]8;;\x1b\\ \x1b[A\x1b[K\x1b]8;;http"))
+	cw.Write([]byte("://x\x1b\\c"))
+
+	if string(w.WrittenData) != "a\rb x.go c" {
+		t.Errorf("Expected sequences to be stripped, got %q", w.WrittenData)
+	}
+
+	expected := []string{"A2", "J0", "A1", "K0"}
+
+	if !slices.Equal(controls, expected) {
+		t.Errorf("Expected controls %v, got %v", expected, controls)
+	}
+}
@@ -0,0 +1,146 @@
+//go:build windows
+
+package humanslog
+
+import (
+	"io"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	kernel32                       = syscall.NewLazyDLL("kernel32.dll")
+	procSetConsoleTextAttribute    = kernel32.NewProc("SetConsoleTextAttribute")
+	procGetConsoleScreenBufferInfo = kernel32.NewProc("GetConsoleScreenBufferInfo")
+	procGetConsoleMode             = kernel32.NewProc("GetConsoleMode")
+	procSetConsoleMode             = kernel32.NewProc("SetConsoleMode")
+	procSetConsoleCursorPosition   = kernel32.NewProc("SetConsoleCursorPosition")
+	procFillConsoleOutputCharacter = kernel32.NewProc("FillConsoleOutputCharacterW")
+	procFillConsoleOutputAttribute = kernel32.NewProc("FillConsoleOutputAttribute")
+)
+
+const enableVirtualTerminalProcessing = 0x0004
+
+type consoleCoord struct {
+	x int16
+	y int16
+}
+
+// uintptr packs c for passing a COORD by value
+func (c consoleCoord) uintptr() uintptr {
+	return uintptr(uint16(c.x)) | uintptr(uint16(c.y))<<16
+}
+
+type consoleSmallRect struct {
+	left   int16
+	top    int16
+	right  int16
+	bottom int16
+}
+
+type consoleScreenBufferInfo struct {
+	size              consoleCoord
+	cursorPosition    consoleCoord
+	attributes        uint16
+	window            consoleSmallRect
+	maximumWindowSize consoleCoord
+}
+
+// NewConsoleWriter returns a writer translating the handler's ANSI colors into SetConsoleTextAttribute calls,
+// and moving the cursor and erasing for records rewritten in place into SetConsoleCursorPosition and fill calls.
+// Use it for legacy Windows consoles where VT processing can't be enabled.
+// When f is not a console, f is returned unchanged.
+func NewConsoleWriter(f *os.File) io.Writer {
+	handle := f.Fd()
+
+	var info consoleScreenBufferInfo
+	r, _, _ := procGetConsoleScreenBufferInfo.Call(handle, uintptr(unsafe.Pointer(&info)))
+	if r == 0 {
+		return f
+	}
+
+	return newConsoleWriter(f, info.attributes, func(attr uint16) error {
+		r, _, err := procSetConsoleTextAttribute.Call(handle, uintptr(attr))
+		if r == 0 {
+			return err
+		}
+
+		return nil
+	}, func(cmd byte, n int) error {
+		return consoleControl(handle, cmd, n)
+	})
+}
+
+// consoleControl moves the cursor up n lines for 'A' and blanks the cells from the cursor to the end of
+// the screen for 'J' or of the line for 'K'
+func consoleControl(handle uintptr, cmd byte, n int) error {
+	var info consoleScreenBufferInfo
+	r, _, err := procGetConsoleScreenBufferInfo.Call(handle, uintptr(unsafe.Pointer(&info)))
+	if r == 0 {
+		return err
+	}
+
+	pos := info.cursorPosition
+	if cmd == 'A' {
+		pos.y = int16(max(int(pos.y)-n, 0))
+		r, _, err = procSetConsoleCursorPosition.Call(handle, pos.uintptr())
+		if r == 0 {
+			return err
+		}
+
+		return nil
+	}
+
+	cells := uint32(info.size.x - pos.x)
+	if cmd == 'J' {
+		cells += uint32(info.size.y-pos.y-1) * uint32(info.size.x)
+	}
+
+	var written uint32
+	r, _, err = procFillConsoleOutputCharacter.Call(handle, ' ', uintptr(cells), pos.uintptr(), uintptr(unsafe.Pointer(&written)))
+	if r == 0 {
+		return err
+	}
+
+	r, _, err = procFillConsoleOutputAttribute.Call(handle, uintptr(info.attributes), uintptr(cells), pos.uintptr(), uintptr(unsafe.Pointer(&written)))
+	if r == 0 {
+		return err
+	}
+
+	return nil
+}
+
+// fileTerminalWidth returns the number of columns of the console window, 0 when f isn't a console
+func fileTerminalWidth(f *os.File) int {
+	var info consoleScreenBufferInfo
+	r, _, _ := procGetConsoleScreenBufferInfo.Call(f.Fd(), uintptr(unsafe.Pointer(&info)))
+	if r == 0 {
+		return 0
+	}
+
+	return int(info.window.right-info.window.left) + 1
+}
+
+// enableColors turns on VT processing of the console out writes to and reports whether it
+// can render ANSI colors. Redirected output and legacy consoles without VT support can't.
+// Writers other than files are left to the caller.
+func enableColors(out io.Writer) bool {
+	f, ok := out.(*os.File)
+	if !ok {
+		return true
+	}
+
+	var mode uint32
+	r, _, _ := procGetConsoleMode.Call(f.Fd(), uintptr(unsafe.Pointer(&mode)))
+	if r == 0 {
+		return false
+	}
+
+	if mode&enableVirtualTerminalProcessing != 0 {
+		return true
+	}
+
+	r, _, _ = procSetConsoleMode.Call(f.Fd(), uintptr(mode|enableVirtualTerminalProcessing))
+	return r != 0
+}
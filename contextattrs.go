@@ -0,0 +1,25 @@
+package humanslog
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+)
+
+type contextAttrsKey struct{}
+
+// ContextWithAttrs returns a copy of ctx carrying attrs, the handler adds them to every record logged with the context,
+// e.g. request_id set by a middleware. Attributes of parent contexts are kept.
+func ContextWithAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	return context.WithValue(ctx, contextAttrsKey{}, append(slices.Clip(contextAttrs(ctx)), attrs...))
+}
+
+// contextAttrs returns attributes added to ctx by ContextWithAttrs
+func contextAttrs(ctx context.Context) []slog.Attr {
+	if ctx == nil {
+		return nil
+	}
+
+	attrs, _ := ctx.Value(contextAttrsKey{}).([]slog.Attr)
+	return attrs
+}
@@ -0,0 +1,75 @@
+package humanslog
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+)
+
+func Test_ContextAttrs(t *testing.T) {
+	testContextWithAttrs(t)
+	testContextWithAttrsNested(t)
+	testContextWithAttrsFilter(t)
+}
+
+func testContextWithAttrs(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true}))
+
+	ctx := ContextWithAttrs(context.Background(), slog.String("request_id", "r1"))
+	logger.WithGroup("g").With("a", 1).InfoContext(ctx, "msg", "b", 2)
+	logger.Info("no context")
+
+	expected := "[]  INFO  msg g.b=2 g.a=1 request_id=r1\n[]  INFO  no context\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testContextWithAttrsNested(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true}))
+
+	parent := ContextWithAttrs(context.Background(), slog.String("request_id", "r1"))
+	child := ContextWithAttrs(parent, slog.Int("user_id", 7))
+	sibling := ContextWithAttrs(parent, slog.Int("user_id", 8))
+
+	logger.InfoContext(child, "child")
+	logger.InfoContext(sibling, "sibling")
+	logger.InfoContext(parent, "parent")
+
+	expected := "[]  INFO  child request_id=r1 user_id=7\n" +
+		"[]  INFO  sibling request_id=r1 user_id=8\n" +
+		"[]  INFO  parent request_id=r1\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testContextWithAttrsFilter(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{
+		TimeFormat: "[]",
+		NoColor:    true,
+		Filter: func(_ context.Context, r slog.Record) bool {
+			keep := true
+			r.Attrs(func(a slog.Attr) bool {
+				keep = a.Key != "healthcheck"
+				return keep
+			})
+			return keep
+		},
+	}))
+
+	logger.InfoContext(ContextWithAttrs(context.Background(), slog.Bool("healthcheck", true)), "dropped")
+	logger.Info("kept")
+
+	expected := "[]  INFO  kept\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
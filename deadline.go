@@ -0,0 +1,43 @@
+package humanslog
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// deadlineWarning is the remaining time below which the deadline is rendered red
+const deadlineWarning = 100 * time.Millisecond
+
+// formatDeadline appends the remaining time of the ctx deadline at the time of the record
+func (h *Handler) formatDeadline(b []byte, ctx context.Context, r *slog.Record) []byte {
+	if !h.opts.ShowDeadline || ctx == nil {
+		return b
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return b
+	}
+
+	left := deadline.Sub(r.Time)
+	if left <= 0 {
+		b = append(b, ' ')
+		return append(b, h.colorString([]byte("ctx deadline exceeded"), fgRed)...)
+	}
+
+	if left >= time.Second {
+		left = left.Round(100 * time.Millisecond)
+	} else {
+		left = left.Round(time.Millisecond)
+	}
+
+	s := []byte(h.glyphs("ctx≈" + left.String() + " left"))
+
+	b = append(b, ' ')
+	if left < deadlineWarning {
+		return append(b, h.colorString(s, fgRed)...)
+	}
+
+	return append(b, h.faintedText(s)...)
+}
@@ -0,0 +1,74 @@
+package humanslog
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func Test_Deadline(t *testing.T) {
+	testDeadline(t)
+	testDeadlineNearlyExpired(t)
+	testDeadlineExceeded(t)
+	testDeadlineMissing(t)
+}
+
+func testDeadlineRecord(t *testing.T, opts *Options, left time.Duration) string {
+	t.Helper()
+
+	w := &MockWriter{}
+	h := NewHandler(w, opts)
+
+	now := time.Now()
+	ctx, cancel := context.WithDeadline(context.Background(), now.Add(left))
+	defer cancel()
+
+	r := slog.NewRecord(now, slog.LevelInfo, "msg", 0)
+	r.AddAttrs(slog.String("a", "b"))
+	if err := h.Handle(ctx, r); err != nil {
+		t.Fatal(err)
+	}
+
+	return string(w.WrittenData)
+}
+
+func testDeadline(t *testing.T) {
+	got := testDeadlineRecord(t, &Options{TimeFormat: "[]", NoColor: true, ShowDeadline: true}, 1234*time.Millisecond)
+	expected := "[]  INFO  msg a=b ctx≈1.2s left\n"
+
+	if got != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, got)
+	}
+}
+
+func testDeadlineNearlyExpired(t *testing.T) {
+	got := testDeadlineRecord(t, &Options{TimeFormat: "[]", ShowDeadline: true}, 50*time.Millisecond)
+	expected := "\x1b[2m[]\x1b[0m \x1b[42m\x1b[30m INFO \x1b[0m msg \x1b[90ma=\x1b[0mb \x1b[31mctx≈50ms left\x1b[0m\n"
+
+	if got != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, got)
+	}
+}
+
+func testDeadlineExceeded(t *testing.T) {
+	got := testDeadlineRecord(t, &Options{TimeFormat: "[]", NoColor: true, ShowDeadline: true}, -time.Second)
+	expected := "[]  INFO  msg a=b ctx deadline exceeded\n"
+
+	if got != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, got)
+	}
+}
+
+func testDeadlineMissing(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, ShowDeadline: true}))
+
+	logger.InfoContext(context.Background(), "msg")
+
+	expected := "[]  INFO  msg\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
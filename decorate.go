@@ -0,0 +1,37 @@
+package humanslog
+
+import (
+	"bytes"
+	"log/slog"
+)
+
+// decorateLine adds LinePrefix and LineSuffix to the first line of the record rendered at b[start:]
+func (h *Handler) decorateLine(b []byte, start int, r *slog.Record) []byte {
+	if h.opts.LinePrefix == nil && h.opts.LineSuffix == nil {
+		return b
+	}
+
+	var prefix, suffix []byte
+	if h.opts.LinePrefix != nil {
+		prefix = h.opts.LinePrefix(*r)
+	}
+	if h.opts.LineSuffix != nil {
+		suffix = h.opts.LineSuffix(*r)
+	}
+	if len(prefix) == 0 && len(suffix) == 0 {
+		return b
+	}
+
+	end := len(b)
+	if i := bytes.IndexByte(b[start:], '\n'); i >= 0 {
+		end = start + i
+	}
+
+	rest := append([]byte(nil), b[end:]...)
+	line := append([]byte(nil), b[start:end]...)
+
+	b = append(b[:start], prefix...)
+	b = append(b, line...)
+	b = append(b, suffix...)
+	return append(b, rest...)
+}
@@ -0,0 +1,71 @@
+package humanslog
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+func Test_LineDecorations(t *testing.T) {
+	testLinePrefixSuffix(t)
+	testLineSuffixMultiline(t)
+	testLinePrefixByLevel(t)
+}
+
+func testLinePrefixSuffix(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{
+		TimeFormat: "[]",
+		NoColor:    true,
+		LinePrefix: func(r slog.Record) []byte { return []byte("pod-1 | ") },
+		LineSuffix: func(r slog.Record) []byte { return []byte(" (main)") },
+	}))
+
+	logger.Info("msg", "n", 1)
+
+	expected := "pod-1 | []  INFO  msg n=1 (main)\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testLineSuffixMultiline(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{
+		TimeFormat: "[]",
+		NoColor:    true,
+		LineSuffix: func(r slog.Record) []byte { return []byte(" <") },
+	}))
+
+	logger.Info("msg", "s", "a\nb")
+
+	expected := "[]  INFO  msg s=a <\nb\n\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testLinePrefixByLevel(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{
+		TimeFormat: "[]",
+		NoColor:    true,
+		LinePrefix: func(r slog.Record) []byte {
+			if r.Level >= slog.LevelError {
+				return []byte("!! ")
+			}
+			return nil
+		},
+	}))
+
+	logger.Info("ok")
+	logger.Error("failed")
+
+	expected := "[]  INFO  ok\n!! []  ERROR  failed\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
@@ -0,0 +1,147 @@
+package humanslog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"hash/maphash"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// deduplicator collapses consecutive identical records, it's shared by handlers derived with With and WithGroup
+type deduplicator struct {
+	mu      sync.Mutex
+	window  time.Duration
+	inPlace bool
+	seed    maphash.Seed
+
+	key    uint64
+	last   time.Time
+	count  int
+	record []byte
+}
+
+func newDeduplicator(window time.Duration, inPlace bool) *deduplicator {
+	return &deduplicator{window: window, inPlace: inPlace, seed: maphash.MakeSeed()}
+}
+
+// dedupKey hashes the level, message and attributes of the record, of the handler and of the context without
+// rendering them, so ReplaceAttr, LogValue and other hooks run once per record
+func (h *Handler) dedupKey(ctx context.Context, r *slog.Record) uint64 {
+	var mh maphash.Hash
+	mh.SetSeed(h.dedup.seed)
+
+	mh.WriteString(r.Level.String())
+	mh.WriteByte(0)
+	mh.WriteString(r.Message)
+
+	for _, goa := range h.goas {
+		if goa.group != "" {
+			mh.WriteString("\x00[" + goa.group)
+		}
+		for _, a := range goa.attrs {
+			writeDedupAttr(&mh, a)
+		}
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		writeDedupAttr(&mh, a)
+		return true
+	})
+	for _, a := range contextAttrs(ctx) {
+		writeDedupAttr(&mh, a)
+	}
+
+	return mh.Sum64()
+}
+
+// writeDedupAttr hashes the key and value of a, values of LogValuers aren't resolved
+func writeDedupAttr(mh *maphash.Hash, a slog.Attr) {
+	mh.WriteByte(0)
+	mh.WriteString(a.Key)
+	mh.WriteByte('=')
+
+	if a.Value.Kind() == slog.KindGroup {
+		mh.WriteByte('{')
+		for _, ga := range a.Value.Group() {
+			writeDedupAttr(mh, ga)
+		}
+		mh.WriteByte('}')
+		return
+	}
+
+	mh.WriteString(a.Value.String())
+}
+
+// outputDeduplicated writes b unless it repeats the previous record within the window.
+// Repeats rewrite the previous record with a ×N counter on a terminal, elsewhere
+// they are reported as "last record repeated N times" when the run ends.
+func (h *Handler) outputDeduplicated(ctx context.Context, b []byte, r *slog.Record) error {
+	d := h.dedup
+	key := h.dedupKey(ctx, r)
+
+	t := r.Time
+	if t.IsZero() {
+		t = time.Now()
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.count > 0 && key == d.key && t.Sub(d.last) <= d.window {
+		d.count++
+		d.last = t
+
+		if !d.inPlace {
+			return nil
+		}
+
+		return h.output(h.repeatedInPlace(d.record, d.count))
+	}
+
+	err := h.flushRepeated(d)
+
+	d.key, d.last, d.count = key, t, 1
+	if d.inPlace {
+		d.record = append(d.record[:0], b...)
+	}
+
+	return errors.Join(err, h.output(b))
+}
+
+// repeatedInPlace moves the cursor back over record and renders it again with the counter
+func (h *Handler) repeatedInPlace(record []byte, count int) []byte {
+	lines := bytes.Count(record, []byte("\n"))
+
+	b := make([]byte, 0, len(record)+32)
+	if lines > 0 {
+		b = append(b, "\x1b["...)
+		b = strconv.AppendInt(b, int64(lines), 10)
+		b = append(b, 'A')
+	}
+	b = append(b, "\r\x1b[J"...)
+
+	counter := h.faintedText([]byte(h.glyphs(" ×" + strconv.Itoa(count))))
+	if i := bytes.IndexByte(record, '\n'); i >= 0 {
+		b = append(b, record[:i]...)
+		b = append(b, counter...)
+		return append(b, record[i:]...)
+	}
+
+	b = append(b, record...)
+	return append(b, counter...)
+}
+
+// flushRepeated reports repeats of the previous record when they aren't rewritten in place, d.mu must be held
+func (h *Handler) flushRepeated(d *deduplicator) error {
+	if d.inPlace || d.count < 2 {
+		return nil
+	}
+
+	repeated := d.count - 1
+	d.count = 1
+
+	return h.output(append(h.faintedText([]byte("last record repeated "+strconv.Itoa(repeated)+" times")), '\n'))
+}
@@ -0,0 +1,118 @@
+package humanslog
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func Test_Dedup(t *testing.T) {
+	testDedupRepeatedLine(t)
+	testDedupWindow(t)
+	testDedupFlush(t)
+	testDedupInPlace(t)
+	testDedupHooksRunOnce(t)
+}
+
+func testDedupRepeatedLine(t *testing.T) {
+	w := &MockWriter{}
+	h := NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, DedupWindow: time.Second})
+
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		logAt(h.WithAttrs([]slog.Attr{slog.Int("a", 1)}), now, slog.LevelInfo, "same")
+	}
+	logAt(h.WithAttrs([]slog.Attr{slog.Int("a", 2)}), now, slog.LevelInfo, "same")
+
+	expected := "[]  INFO  same a=1\nlast record repeated 2 times\n[]  INFO  same a=2\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testDedupWindow(t *testing.T) {
+	w := &MockWriter{}
+	h := NewHandler(w, &Options{TimeFormat: "[05]", NoColor: true, DedupWindow: time.Second})
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	logAt(h, start, slog.LevelInfo, "tick")
+	logAt(h, start.Add(500*time.Millisecond), slog.LevelInfo, "tick")
+	logAt(h, start.Add(3*time.Second), slog.LevelInfo, "tick")
+
+	expected := "[00]  INFO  tick\nlast record repeated 1 times\n[03]  INFO  tick\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testDedupFlush(t *testing.T) {
+	w := &MockWriter{}
+	h := NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, DedupWindow: time.Second})
+
+	now := time.Now()
+	logAt(h, now, slog.LevelWarn, "retrying")
+	logAt(h, now, slog.LevelWarn, "retrying")
+
+	if err := h.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "[]  WARN  retrying\nlast record repeated 1 times\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testDedupInPlace(t *testing.T) {
+	w := &MockWriter{}
+	h := NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, DedupWindow: time.Second})
+	h.dedup.inPlace = true
+
+	now := time.Now()
+	logAt(h, now, slog.LevelInfo, "same")
+	logAt(h, now, slog.LevelInfo, "same")
+	logAt(h, now, slog.LevelInfo, "same")
+
+	expected := "[]  INFO  same\n" +
+		"\x1b[1A\r\x1b[J[]  INFO  same ×2\n" +
+		"\x1b[1A\r\x1b[J[]  INFO  same ×3\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+type countingValuer struct{ n *int }
+
+func (v countingValuer) LogValue() slog.Value {
+	*v.n++
+	return slog.IntValue(*v.n)
+}
+
+func testDedupHooksRunOnce(t *testing.T) {
+	replaced := 0
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{
+		TimeFormat:  "[]",
+		NoColor:     true,
+		DedupWindow: time.Second,
+		HandlerOptions: &slog.HandlerOptions{ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == "v" {
+				replaced++
+			}
+			return a
+		}},
+	}))
+
+	n := 0
+	logger.Info("msg", "v", countingValuer{&n})
+	logger.Info("msg", "v", countingValuer{&n})
+
+	if n != 2 || replaced != 2 {
+		t.Errorf("Expected LogValue and ReplaceAttr to run once per record, got %d and %d", n, replaced)
+	}
+}
@@ -1,6 +1,7 @@
 package humanslog
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding"
@@ -9,9 +10,13 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"maps"
+	"math"
 	"net/url"
 	"reflect"
+	"regexp"
 	"runtime"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -20,26 +25,83 @@ import (
 	"unicode/utf8"
 )
 
-type developHandler struct {
-	opts Options
-	goas []groupOrAttrs
-	mu   sync.Mutex
-	out  io.Writer
+// Handler is a slog.Handler rendering records for humans, create it with NewHandler
+type Handler struct {
+	opts     Options
+	goas     []groupOrAttrs
+	mu       sync.Locker
+	out      *sharedWriter
+	recent   *recentRecords
+	async    *asyncWriter
+	sampler  *sampler
+	dedup    *deduplicator
+	seen     *errorTracker
+	table    *tableLayout
+	progress *progressDisplay
+	replay   slog.Handler
+	level    *slog.LevelVar
+
+	// NoColor as set in Options, before it was changed by the detection of the writer
+	noColor bool
+}
+
+const (
+	// Unlimited as a print size prints all elements
+	Unlimited = ^uint(0)
+
+	// HideElements as a print size prints only the length
+	HideElements = ^uint(0) - 1
+)
+
+// NoWrap as WrapWidth keeps inline attributes on the line of the message, also on a terminal
+const NoWrap = -1
+
+// printLimit returns the number of elements printed for the print size option
+func printLimit(size uint) int {
+	switch size {
+	case Unlimited:
+		return math.MaxInt
+	case HideElements:
+		return 0
+	default:
+		return int(min(size, math.MaxInt))
+	}
 }
 
 type Options struct {
 	// You can use standard slog.HandlerOptions, that would be used in production
 	*slog.HandlerOptions
 
-	// Max number of printed elements in slice.
+	// Max number of printed elements in slice, Unlimited prints all and HideElements none.
 	MaxSlicePrintSize uint
 
-	// If the attributes should be sorted by keys
+	// Max number of printed map entries in key order, Unlimited prints all and HideElements none.
+	MaxMapPrintSize uint
+
+	// If the attributes should be sorted by keys, the same as SortMode SortAlpha
 	SortKeys bool
 
+	// Order of attributes, keys of KeyOrder come first unless it's SortAlpha
+	SortMode SortMode
+
+	// Keys rendered before other attributes, in this order, e.g. "err", "request_id", "duration"
+	KeyOrder []string
+
+	// Keys of attributes which are not rendered, "*" globs match keys and dotted group paths, e.g. "caller", "http.*"
+	HideKeys []string
+
+	// If set, only attributes with matching keys are rendered, groups are kept when any of their attributes matches
+	OnlyKeys []string
+
 	// Time format for timestamp, default format is "[15:04:05]"
 	TimeFormat string
 
+	// Time zone of timestamps, e.g. time.UTC, the local time zone by default
+	TimeLocation *time.Location
+
+	// Replaces the time of records, e.g. a fixed time in tests
+	TimeFunction func() time.Time
+
 	// Add blank line after each log
 	NewLineAfterLog bool
 
@@ -58,6 +120,30 @@ type Options struct {
 	// Set color for Error level, default: humanslog.Red
 	ErrorColor Color
 
+	// Colors of keys, numbers, strings, URLs, types, punctuation, timestamps and source info
+	Colors Colors
+
+	// Styles with emphasis of the elements of Colors and of messages by level
+	Styles Styles
+
+	// Characters before keys in the multiline section showing the kind of the value, e.g. # for numbers
+	Markers Markers
+
+	// Don't show markers before keys in the multiline section
+	NoMarkers bool
+
+	// Text put before the record line, e.g. the pod name or a spinner frame, the record allows to vary it by level
+	LinePrefix func(r slog.Record) []byte
+
+	// Text put at the end of the record line, before the multiline section
+	LineSuffix func(r slog.Record) []byte
+
+	// Render errors and "err"/"error" attributes as a red block under the record line, one cause per line
+	ErrorBlock bool
+
+	// Render errors wrapping multiple errors, e.g. of errors.Join, as a tree instead of joining them with ": "
+	ErrorTree bool
+
 	// Max stack trace frames when unwrapping errors
 	MaxErrorStackTrace uint
 
@@ -69,6 +155,258 @@ type Options struct {
 
 	// Keep same color for whole source info, helpful when you want to open the line of code from terminal, but the ANSI coloring codes are in link itself
 	SameSourceInfoColor bool
+
+	// Lock shared with other handlers writing to the same writer, so their lines don't interleave
+	WriterLock sync.Locker
+
+	// Wrap the writer in a bufio.Writer of this size owned by the handler, call Flush() before exit
+	BufferSize int
+
+	// Mask string values that look like credentials (AWS keys, Bearer tokens, JWTs, PEM private keys),
+	// also in fields of structs and maps. Hex secrets are masked in values of keys like "api_token" or after labels like "secret="
+	MaskSecrets bool
+
+	// With MaskSecrets, also mask random-looking tokens of 20 or more letters and digits, which may catch harmless values
+	MaskHighEntropy bool
+
+	// Called with the dotted attribute key, e.g. "cfg.Token", and the kind of secret when MaskSecrets masked a value
+	OnSecretMasked func(key string, kind string)
+
+	// Render "before" and "after" attributes of a record as a field-level diff, see also humanslog.Diff
+	DiffBeforeAfter bool
+
+	// Render diffs as a unified diff of the JSON representations of the values instead of changed fields
+	UnifiedDiff bool
+
+	// Headers rendered for *http.Request and *http.Response values, nil renders Content-Type and Content-Length
+	HTTPHeaders []string
+
+	// Max bytes of the body rendered for *http.Request and *http.Response values, 0 doesn't render the body.
+	// Only bodies of requests with GetBody set and seekable bodies are read, the body itself is never replaced
+	HTTPBodySize int
+
+	// Render groups as an indented tree in the multiline section instead of inline
+	GroupTree bool
+
+	// Max number of attributes of a group rendered inline as g={a=1 b=2}, larger groups are rendered as an indented block.
+	// When both InlineGroupMaxAttrs and InlineGroupMaxWidth are 0, groups are flattened inline as g.a=1 g.b=2
+	InlineGroupMaxAttrs int
+
+	// Max rendered width of a group rendered inline as g={a=1 b=2}, wider groups are rendered as an indented block
+	InlineGroupMaxWidth int
+
+	// Groups with more attributes are rendered as g={… 12 attrs} unless the level is Debug
+	CollapseGroupsOver int
+
+	// Width of the line after which inline attributes are wrapped onto continuation lines aligned with the message,
+	// 0 wraps at the width of the terminal the handler writes to, other writers aren't wrapped, NoWrap doesn't wrap
+	WrapWidth int
+
+	// Layout of records, FormatHybrid by default
+	Format Format
+
+	// Width messages followed by attributes are padded to, so attributes of consecutive records line up
+	MessagePadding int
+
+	// Separator between keys and values, "=" by default, e.g. ": "
+	KeyValueSeparator string
+
+	// Separator between attributes, a space by default, e.g. ", " or " | "
+	AttrSeparator string
+
+	// Render newlines in the message and values as \n, keeping every record on one line
+	EscapeNewlines bool
+
+	// Replace the non-ASCII markers, ellipses and arrows of the output with ASCII equivalents
+	ASCIIOnly bool
+
+	// Replace {key} placeholders in the message with the values of record attributes, which are left out of the attribute list
+	MessageTemplates bool
+
+	// Rendering of []byte values: BytesAuto shows UTF-8 text as a string and other bytes as hex, or BytesHex or BytesBase64
+	BytesFormat BytesFormat
+
+	// Non-text []byte values longer than this are rendered as a hex dump in the multiline section like values of Hex,
+	// 0 disables it
+	HexDumpOver int
+
+	// Maximum number of 16 byte rows of a hex dump, 16 by default
+	HexDumpRows int
+
+	// Quoting of inline string and error values, QuoteAuto quotes them like slog.TextHandler when they contain spaces,
+	// "=", quotes or control characters
+	QuoteValues QuoteMode
+
+	// Expand tabs in multiline values to tab stops of this width, so they don't break the indentation
+	TabWidth int
+
+	// Wrap the message and string values containing right-to-left text or directional overrides in bidi isolation characters,
+	// so logged input can't visually reorder the rest of the line
+	IsolateBidi bool
+
+	// Render JSON arrays of objects with mostly uniform keys as an aligned table
+	JSONTables bool
+
+	// json.RawMessage, map[string]any and []any values are rendered as JSON, compact on the record line up to this many bytes
+	// and indented in the multiline section when larger, 80 by default
+	JSONInlineSize int
+
+	// JSON values larger than this many bytes are folded, nested objects and arrays are shown as {…} and [… N items], 0 disables
+	JSONFoldSize int
+
+	// Dot-separated paths of values expanded in folded JSON, e.g. "data.items" or "users.*", arrays don't add a path segment
+	JSONExpandKeys []string
+
+	// Don't show the level as an attribute when ReplaceAttr renames the level key
+	HideRenamedLevel bool
+
+	// Badge texts by level, e.g. "I" for slog.LevelInfo or "TRACE" for a custom slog.Level(-8)
+	LevelStrings map[slog.Level]string
+
+	// Width, padding and background of level badges
+	BadgeStyle BadgeStyle
+
+	// Names and colors of levels besides DEBUG, INFO, WARN and ERROR, records use the closest level at or below theirs
+	CustomLevels []LevelSpec
+
+	// Number of source code lines printed around the logging line beneath Error records, requires AddSource
+	SourceSnippetLines int
+
+	// Command printed instead of the source location, %f is replaced with the file and %l with the line (e.g. "code -g %f:%l")
+	EditorCommandTemplate string
+
+	// Minimum levels for packages, matched by package path prefix of the caller (e.g. "github.com/lib/pq": slog.LevelWarn)
+	PackageLevels map[string]slog.Level
+
+	// Only log records whose message matches the pattern
+	IncludePattern *regexp.Regexp
+
+	// Don't log records whose message matches the pattern
+	ExcludePattern *regexp.Regexp
+
+	// Match IncludePattern and ExcludePattern also against the rendered attributes
+	MatchPatternsOnAttrs bool
+
+	// Indent messages of records logged within spans started by StartContext, or reported by SpanDepth
+	IndentSpans bool
+
+	// Returns depth of the active trace span in ctx, e.g. from OpenTelemetry span parents
+	SpanDepth func(ctx context.Context) int
+
+	// Maximum indentation depth of spans
+	MaxSpanDepth int
+
+	// Show remaining time of the context deadline, red when nearly expired
+	ShowDeadline bool
+
+	// Maximum number of inline attributes, the rest is summarized as "+N more" unless the level is Debug
+	MaxAttrs int
+
+	// Number of last formatted records retained for Recent and DumpRecentOnPanic
+	RecentRecords int
+
+	// Tint whole lines of Warn and Error records with the level color and dim Debug records
+	TintLines bool
+
+	// Style whole records whose message or attributes match, the first matching rule is applied,
+	// e.g. a Yellow background for records with a request ID
+	Highlights []HighlightRule
+
+	// Path of a file every record is appended to as a JSON line, unfiltered and without ReplaceAttr, for later analysis
+	ReplayFile string
+
+	// Disable colors when the writer isn't a terminal, e.g. output piped to a file or CI log
+	AutoDetectTTY bool
+
+	// Mask attribute values by key patterns and value detectors, including struct fields, map entries and JSON strings
+	Redact *RedactOptions
+
+	// How the file of the source location is shown, full path by default
+	SourcePath SourcePath
+
+	// Formats the source location, overrides SourcePath and EditorCommandTemplate
+	SourceFormatter func(s *slog.Source) string
+
+	// Number of frames above the logging call reported as the source, for logging facades wrapping slog, see WithCallerSkip
+	CallerSkip int
+
+	// Make source locations and URLs clickable with OSC 8 hyperlinks, supported e.g. by iTerm2, WezTerm and VS Code
+	Hyperlinks bool
+
+	// Hyperlink target of source locations, %f is replaced with the file and %l with the line (e.g. "vscode://file/%f:%l"), file:// URL by default
+	SourceLinkTemplate string
+
+	// Key suffixes of integer attributes rendered as byte sizes, e.g. "_bytes", "size" renders 4.2 MiB (4404019)
+	ByteSizeKeys []string
+
+	// Key suffixes of integer attributes rendered as counts, e.g. "len", "count" renders 1.3k (1342)
+	CountKeys []string
+
+	// Color duration values green below 100ms, yellow below 1s and red above
+	ColorDurations bool
+
+	// Thresholds of duration values by key suffix, e.g. "db_time", the longest matching suffix takes precedence over ColorDurations
+	DurationThresholds map[string]DurationThresholds
+
+	// Formatters of values by attribute key, the key with groups (e.g. "req.id") takes precedence over the plain key
+	ValueFormatters map[string]func(v slog.Value) []byte
+
+	// Capacity of the queue of records written by a background goroutine, 0 writes synchronously, see Flush() and Close()
+	AsyncQueueSize int
+
+	// Drop records when the async queue is full instead of blocking, the number of dropped records is logged
+	AsyncDropOnFull bool
+
+	// Limits of identical records per level, suppressed records are summarized as "suppressed N similar: message"
+	Sampling map[slog.Level]SamplingRate
+
+	// Align messages and attribute values of consecutive records sharing attribute keys into columns with a header row
+	TableMode bool
+
+	// Rows after which the header row of TableMode is repeated, 0 uses 20, negative only writes it when the columns change
+	TableHeaderEvery int
+
+	// Collapse consecutive identical records logged within this window, updated in place with a ×N counter in a terminal
+	DedupWindow time.Duration
+
+	// Annotate errors seen before within the window with "(seen N× in last 60s)", 0 disables
+	ErrorSeenWindow time.Duration
+
+	// Number of distinct errors tracked for ErrorSeenWindow, the least recently seen are forgotten, default: 256
+	ErrorSeenSize int
+
+	// Ignore the NO_COLOR, CLICOLOR, CLICOLOR_FORCE and FORCE_COLOR environment variables
+	IgnoreEnv bool
+
+	// Maximum bytes of XML and HTML values formatted as indented elements, the rest is truncated, 0 means no limit
+	MaxXMLSize int
+
+	// Highlight string values detected as SQL queries
+	HighlightSQL bool
+
+	// Key suffixes of string attributes always highlighted as SQL, e.g. "query", "sql"
+	SQLKeys []string
+
+	// Put clauses of SQL queries on separate lines in the multiline section
+	FormatSQL bool
+
+	// Maximum nesting depth of slices, maps and structs, deeper values are shown as {…}, 0 means no limit
+	MaxDepth int
+
+	// Visible bytes after which slices, maps and structs are cut off with "… +N more", 0 means no limit
+	MaxValueBytes int
+
+	// Bytes after which string values are cut off with "…(+N bytes)", values of Full are exempt, 0 means no limit
+	MaxStringLength int
+
+	// Drops records for which it returns false, the record includes attributes added by WithAttrs and WithGroup
+	Filter func(ctx context.Context, r slog.Record) bool
+
+	// Minimum levels by the value of the LevelOverrideKey attribute, e.g. {"db": slog.LevelDebug}
+	LevelOverrides map[string]slog.Leveler
+
+	// Attribute key matched against LevelOverrides, "component" by default
+	LevelOverrideKey string
 }
 
 type groupOrAttrs struct {
@@ -76,51 +414,111 @@ type groupOrAttrs struct {
 	attrs []slog.Attr
 }
 
-func NewHandler(out io.Writer, o *Options) *developHandler {
-	h := &developHandler{out: out}
+func NewHandler(out io.Writer, o *Options) *Handler {
+	h := &Handler{out: &sharedWriter{w: out, dest: out}}
 	if o != nil {
 		h.opts = *o
-
 		if o.HandlerOptions != nil {
-			h.opts.HandlerOptions = o.HandlerOptions
-			if o.Level == nil {
-				h.opts.Level = slog.LevelInfo
-			} else {
-				h.opts.Level = o.Level
-			}
-		} else {
-			h.opts.HandlerOptions = &slog.HandlerOptions{
-				Level: slog.LevelInfo,
-			}
+			// own copy, defaults and SetLevel must not change the caller's HandlerOptions
+			ho := *o.HandlerOptions
+			h.opts.HandlerOptions = &ho
 		}
+	}
 
-		if o.MaxSlicePrintSize == 0 {
-			h.opts.MaxSlicePrintSize = 50
-		}
+	h.opts.setDefaults()
+	h.noColor = h.opts.NoColor
+	h.out.terminal.Store(isTerminal(out))
+	h.out.width.Store(int64(terminalWidth(out)))
+	h.detectColors(out)
 
-		if o.TimeFormat == "" {
-			h.opts.TimeFormat = "[15:04:05]"
-		}
+	h.mu = writerLocker(out, h.opts)
+	if h.mu == nil {
+		h.mu = &sync.Mutex{}
+	}
 
-		h.opts.DebugColor = ensureValidColor(o.DebugColor, Blue)
-		h.opts.InfoColor = ensureValidColor(o.InfoColor, Green)
-		h.opts.WarnColor = ensureValidColor(o.WarnColor, Yellow)
-		h.opts.ErrorColor = ensureValidColor(o.ErrorColor, Red)
+	if h.opts.BufferSize > 0 && out != nil {
+		h.out.w = bufio.NewWriterSize(out, h.opts.BufferSize)
+	}
+
+	h.syncOptionState(nil)
+
+	return h
+}
+
+// detectColors sets NoColor from the NoColor option, the environment and out
+func (h *Handler) detectColors(out io.Writer) {
+	h.opts.NoColor = h.noColor
 
+	env := h.opts.colorEnvironment()
+	if env == colorEnvOff {
+		h.opts.NoColor = true
+	}
+
+	if cw, ok := out.(ColorWriter); ok {
+		// ColorWriter decides itself, it isn't a terminal even when it writes to one
+		if !cw.Colored() {
+			h.opts.NoColor = true
+		}
 	} else {
-		h.opts = Options{
-			HandlerOptions:    &slog.HandlerOptions{Level: slog.LevelInfo},
-			MaxSlicePrintSize: 50,
-			SortKeys:          false,
-			TimeFormat:        "[15:04:05]",
-			DebugColor:        Blue,
-			InfoColor:         Green,
-			WarnColor:         Yellow,
-			ErrorColor:        Red,
+		if h.opts.AutoDetectTTY && env != colorEnvForce && !isTerminal(out) {
+			h.opts.NoColor = true
 		}
+
+		if !h.opts.NoColor && !enableColors(out) && env != colorEnvForce {
+			h.opts.NoColor = true
+		}
+	}
+}
+
+// setDefaults fills in zero values with the defaults used by NewHandler
+func (o *Options) setDefaults() {
+	if o.Format == FormatOneLine {
+		o.EscapeNewlines = true
 	}
 
-	return h
+	if o.Format == FormatLogfmt {
+		o.NoColor = true
+	}
+
+	if o.SortKeys && o.SortMode == SortNone {
+		o.SortMode = SortAlpha
+	}
+
+	if o.KeyValueSeparator == "" {
+		o.KeyValueSeparator = "="
+	}
+
+	if o.AttrSeparator == "" {
+		o.AttrSeparator = " "
+	}
+
+	if o.HandlerOptions == nil {
+		o.HandlerOptions = &slog.HandlerOptions{Level: slog.LevelInfo}
+	} else if o.Level == nil {
+		o.Level = slog.LevelInfo
+	}
+
+	if o.MaxSlicePrintSize == 0 {
+		o.MaxSlicePrintSize = 50
+	}
+
+	if o.MaxMapPrintSize == 0 {
+		o.MaxMapPrintSize = 50
+	}
+
+	if o.TimeFormat == "" {
+		o.TimeFormat = "[15:04:05]"
+	}
+
+	// a negative depth would be passed to strings.Repeat, it falls back to the default like zero
+	if o.MaxSpanDepth <= 0 {
+		o.MaxSpanDepth = 10
+	}
+
+	o.DebugColor = ensureValidColor(o.DebugColor, Blue)
+	o.InfoColor = ensureValidColor(o.InfoColor, Green)
+	o.WarnColor = ensureValidColor(o.WarnColor, Yellow)
+	o.ErrorColor = ensureValidColor(o.ErrorColor, Red)
 }
 
 func ensureValidColor(c Color, defaultColor Color) Color {
@@ -131,11 +529,11 @@ func ensureValidColor(c Color, defaultColor Color) Color {
 	return defaultColor
 }
 
-func (h *developHandler) Enabled(ctx context.Context, l slog.Level) bool {
-	return l >= h.opts.Level.Level()
+func (h *Handler) Enabled(ctx context.Context, l slog.Level) bool {
+	return l >= h.minLevel()
 }
 
-func (h *developHandler) WithGroup(s string) slog.Handler {
+func (h *Handler) WithGroup(s string) slog.Handler {
 	if s == "" {
 		return h
 	}
@@ -143,43 +541,290 @@ func (h *developHandler) WithGroup(s string) slog.Handler {
 	return h.withGroupOrAttrs(groupOrAttrs{group: s})
 }
 
-func (h *developHandler) WithAttrs(as []slog.Attr) slog.Handler {
-	if len(as) == 0 {
+func (h *Handler) WithAttrs(as []slog.Attr) slog.Handler {
+	var resolved attributes
+	for _, a := range as {
+		resolved = resolved.appendResolved(a)
+	}
+
+	if len(resolved) == 0 {
 		return h
 	}
 
-	return h.withGroupOrAttrs(groupOrAttrs{attrs: as})
+	return h.withGroupOrAttrs(groupOrAttrs{attrs: resolved})
 }
 
-func (h *developHandler) withGroupOrAttrs(goa groupOrAttrs) *developHandler {
-	h2 := &developHandler{
-		opts: h.opts,
-		goas: make([]groupOrAttrs, len(h.goas)+1),
-		out:  h.out,
+func (h *Handler) withGroupOrAttrs(goa groupOrAttrs) *Handler {
+	h2 := &Handler{
+		opts:     h.opts,
+		goas:     make([]groupOrAttrs, len(h.goas)+1),
+		mu:       h.mu,
+		out:      h.out,
+		recent:   h.recent,
+		async:    h.async,
+		sampler:  h.sampler,
+		dedup:    h.dedup,
+		seen:     h.seen,
+		table:    h.table,
+		progress: h.progress,
+		replay:   h.replay,
+		level:    h.level,
+		noColor:  h.noColor,
 	}
 
 	copy(h2.goas, h.goas)
 	h2.goas[len(h2.goas)-1] = goa
 
+	if h.replay != nil {
+		if goa.group != "" {
+			h2.replay = h.replay.WithGroup(goa.group)
+		} else {
+			h2.replay = h.replay.WithAttrs(goa.attrs)
+		}
+	}
+
+	return h2
+}
+
+// Clone returns a copy of the handler with its own Options, sharing the writer and lock
+func (h *Handler) Clone() *Handler {
+	h2 := &Handler{
+		opts:     h.opts,
+		goas:     make([]groupOrAttrs, len(h.goas)),
+		mu:       h.mu,
+		out:      h.out,
+		recent:   h.recent,
+		async:    h.async,
+		sampler:  h.sampler,
+		dedup:    h.dedup,
+		seen:     h.seen,
+		table:    h.table,
+		progress: h.progress,
+		replay:   h.replay,
+		level:    h.level,
+		noColor:  h.noColor,
+	}
+
+	copy(h2.goas, h.goas)
+
+	ho := *h.opts.HandlerOptions
+	h2.opts.HandlerOptions = &ho
+
+	return h2
+}
+
+// Options returns a copy of the options of the handler, with defaults applied
+func (h *Handler) Options() Options {
+	o := h.opts
+	ho := *h.opts.HandlerOptions
+	if h.level != nil {
+		ho.Level = h.level.Level()
+	}
+	o.HandlerOptions = &ho
+
+	return o
+}
+
+// WithOptions returns a clone of the handler with options modified by f, e.g. per-subsystem NoColor. Colors are
+// detected again for the writer. Changing AsyncQueueSize or AsyncDropOnFull starts a separate async writer,
+// Close must be called on both handlers then.
+func (h *Handler) WithOptions(f func(o *Options)) *Handler {
+	h2 := h.Clone()
+	if f != nil {
+		f(&h2.opts)
+	}
+
+	h2.opts.setDefaults()
+	if h2.opts.NoColor != h.opts.NoColor {
+		h2.noColor = h2.opts.NoColor
+	}
+	h2.detectColors(h2.out.dest)
+
+	if l := writerLocker(h2.out.dest, h2.opts); l != nil {
+		h2.mu = l
+	}
+
+	h2.syncOptionState(&h.opts)
+
 	return h2
 }
 
-func (h *developHandler) Handle(ctx context.Context, r slog.Record) error {
-	b := make([]byte, 0, 1024)
+// syncOptionState creates the state of options, e.g. the sampler of Sampling. Handlers of WithOptions share the state
+// of options unchanged from parent with the parent handler, the state of changed options is created anew.
+func (h *Handler) syncOptionState(parent *Options) {
+	if l, ok := h.opts.Level.(slog.Level); !ok {
+		h.level = nil
+	} else if parent == nil || h.level == nil || parent.Level != slog.Leveler(l) {
+		h.level = &slog.LevelVar{}
+		h.level.Set(l)
+	}
 
-	// Use hybrid format: inline fields on one line + multiline fields at end
-	b = h.formatOneLine(b, &r)
+	if parent == nil || h.opts.RecentRecords != parent.RecentRecords {
+		h.recent = nil
+		if h.opts.RecentRecords > 0 {
+			h.recent = newRecentRecords(h.opts.RecentRecords)
+		}
+	}
+
+	if parent == nil || !maps.Equal(h.opts.Sampling, parent.Sampling) {
+		h.sampler = nil
+		if len(h.opts.Sampling) > 0 {
+			h.sampler = newSampler()
+		}
+	}
+
+	terminal := h.out.terminal.Load()
+
+	if parent == nil || h.opts.DedupWindow != parent.DedupWindow || h.opts.NoColor != parent.NoColor {
+		h.dedup = nil
+		if h.opts.DedupWindow > 0 {
+			h.dedup = newDeduplicator(h.opts.DedupWindow, !h.opts.NoColor && terminal)
+		}
+	}
+
+	if parent == nil || h.opts.ErrorSeenWindow != parent.ErrorSeenWindow || h.opts.ErrorSeenSize != parent.ErrorSeenSize {
+		h.seen = nil
+		if h.opts.ErrorSeenWindow > 0 {
+			h.seen = newErrorTracker(h.opts.ErrorSeenWindow, h.opts.ErrorSeenSize)
+		}
+	}
+
+	if parent == nil || h.opts.TableMode != parent.TableMode {
+		h.table = nil
+		if h.opts.TableMode {
+			h.table = &tableLayout{}
+		}
+	}
+
+	if parent == nil || h.opts.NoColor != parent.NoColor {
+		h.progress = nil
+		if !h.opts.NoColor {
+			h.progress = &progressDisplay{terminal: terminal}
+		}
+	}
+
+	if parent == nil || h.opts.ReplayFile != parent.ReplayFile || h.opts.AddSource != parent.AddSource {
+		h.replay = nil
+		if h.opts.ReplayFile != "" {
+			h.replay = newReplayHandler(h.opts.ReplayFile, h.opts.AddSource)
+			for _, goa := range h.goas {
+				if goa.group != "" {
+					h.replay = h.replay.WithGroup(goa.group)
+				} else {
+					h.replay = h.replay.WithAttrs(goa.attrs)
+				}
+			}
+		}
+	}
+
+	if parent == nil || h.opts.AsyncQueueSize != parent.AsyncQueueSize || h.opts.AsyncDropOnFull != parent.AsyncDropOnFull {
+		h.async = nil
+		if h.opts.AsyncQueueSize > 0 {
+			h.async = newAsyncWriter(h.opts.AsyncQueueSize, h.opts.AsyncDropOnFull, func(b []byte) error {
+				h.mu.Lock()
+				defer h.mu.Unlock()
+
+				return h.write(b)
+			})
+		}
+	}
+}
+
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	r.PC = h.callerPC(ctx, r.PC)
+	r.Time = h.recordTime(r.Time)
+
+	if h.replay != nil {
+		return errors.Join(h.replay.Handle(ctx, r.Clone()), h.handle(ctx, r))
+	}
+
+	return h.handle(ctx, r)
+}
+
+func (h *Handler) handle(ctx context.Context, r slog.Record) error {
+	if (len(h.opts.PackageLevels) > 0 || len(h.opts.LevelOverrides) > 0) && r.Level < h.recordLevel(&r) {
+		return nil
+	}
+
+	if !h.filterAllows(ctx, r) {
+		return nil
+	}
+
+	if !h.opts.MatchPatternsOnAttrs && !h.patternsAllow(r.Message, nil) {
+		return nil
+	}
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	b, sampled := h.sample(ctx, (*buf)[:0], &r)
+	if !sampled {
+		*buf = b
+		return h.output(b)
+	}
+
+	start := len(b)
+	b = h.formatRecord(ctx, b, &r)
+	if h.seen != nil {
+		b = h.annotateSeen(b, start, &r)
+	}
+	b = h.tintLines(b, r.Level)
+	b = h.highlightLines(b)
+	*buf = b
+
+	if h.opts.MatchPatternsOnAttrs && !h.patternsAllow(r.Message, b) {
+		return nil
+	}
+
+	if h.progress != nil && isProgressRecord(r) {
+		return h.outputFrame(b, true)
+	}
+
+	if h.dedup != nil {
+		return h.outputDeduplicated(ctx, b, &r)
+	}
+
+	return h.output(b)
+}
+
+// output writes the rendered records, or queues them in the async mode
+func (h *Handler) output(b []byte) error {
+	return h.outputFrame(b, false)
+}
+
+// outputFrame writes b, progress records replace the previous progress record on a terminal
+func (h *Handler) outputFrame(b []byte, progress bool) error {
+	if len(b) == 0 {
+		return nil
+	}
+
+	if h.progress != nil {
+		h.progress.mu.Lock()
+		defer h.progress.mu.Unlock()
+
+		b = h.progress.frame(b, progress)
+	}
+
+	if h.async != nil {
+		if h.recent != nil {
+			h.recent.add(b)
+		}
+
+		return h.async.enqueue(b)
+	}
 
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
-	_, err := h.out.Write(b)
+	if h.recent != nil {
+		h.recent.add(b)
+	}
 
-	return err
+	return h.write(b)
 }
 
 // containsMultiline checks if the message or any attribute contains newlines
-func (h *developHandler) containsMultiline(r slog.Record) bool {
+func (h *Handler) containsMultiline(r slog.Record) bool {
 	// Check message
 	if strings.Contains(r.Message, "\n") {
 		return true
@@ -201,7 +846,7 @@ func (h *developHandler) containsMultiline(r slog.Record) bool {
 
 // attrContainsNewline recursively checks if an attribute contains newlines
 // Only checks string types - other types (errors, structs, etc.) should stay inline
-func (h *developHandler) attrContainsNewline(a slog.Attr) bool {
+func (h *Handler) attrContainsNewline(a slog.Attr) bool {
 	switch a.Value.Kind() {
 	case slog.KindString:
 		return strings.Contains(a.Value.String(), "\n")
@@ -224,7 +869,7 @@ func (h *developHandler) attrContainsNewline(a slog.Attr) bool {
 
 // attrContainsStruct checks if an attribute contains a struct
 // Structs should be moved to multiline section for proper formatting
-func (h *developHandler) attrContainsStruct(a slog.Attr) bool {
+func (h *Handler) attrContainsStruct(a slog.Attr) bool {
 	switch a.Value.Kind() {
 	case slog.KindGroup:
 		// Recursively check group members
@@ -235,7 +880,19 @@ func (h *developHandler) attrContainsStruct(a slog.Attr) bool {
 		}
 	case slog.KindAny:
 		av := a.Value.Any()
-		if av == nil {
+		if av == nil || isHTTPValue(av) {
+			return false
+		}
+
+		if _, _, ok := typeFormatter(reflect.ValueOf(av)); ok {
+			return false
+		}
+
+		if _, ok := av.(sqlNull); ok {
+			return false
+		}
+
+		if isTextAppender(av) {
 			return false
 		}
 
@@ -259,10 +916,16 @@ func (h *developHandler) attrContainsStruct(a slog.Attr) bool {
 // formatOneLine formats the log record in a hybrid format:
 // - One line with all inline fields (no newlines)
 // - Multiline fields appended at the end in readable format
-func (h *developHandler) formatOneLine(b []byte, r *slog.Record) []byte {
-	// Timestamp
-	b = append(b, h.faintedText([]byte(r.Time.Format(h.opts.TimeFormat)))...)
-	b = append(b, ' ')
+func (h *Handler) formatOneLine(ctx context.Context, b []byte, r *slog.Record) []byte {
+	start := len(b)
+
+	// Timestamp, zero time is omitted
+	if !r.Time.IsZero() {
+		b = h.appendCode(b, h.timeCode())
+		b = r.Time.AppendFormat(b, h.opts.TimeFormat)
+		b = h.appendCode(b, resetColor)
+		b = append(b, ' ')
+	}
 
 	// Source info if enabled
 	if h.opts.AddSource {
@@ -276,162 +939,442 @@ func (h *developHandler) formatOneLine(b []byte, r *slog.Record) []byte {
 		if h.opts.ReplaceAttr != nil {
 			attr := h.opts.ReplaceAttr([]string{}, slog.Any(slog.SourceKey, s))
 			if attr.Key != "" {
-				sourceStr := fmt.Sprintf("%s:%d", s.File, s.Line)
-				b = append(b, h.colorString([]byte(sourceStr), fgWhite)...)
+				b = append(b, h.hyperlink(h.colorString([]byte(h.sourceString(s)), h.sourceColor()), h.sourceLink(s.File, s.Line))...)
 				b = append(b, ' ')
 			}
 		} else {
-			sourceStr := fmt.Sprintf("%s:%d", s.File, s.Line)
-			b = append(b, h.colorString([]byte(sourceStr), fgWhite)...)
+			b = append(b, h.hyperlink(h.colorString([]byte(h.sourceString(s)), h.sourceColor()), h.sourceLink(s.File, s.Line))...)
 			b = append(b, ' ')
 		}
 	}
 
-	// Level
+	// Level, when ReplaceAttr renames the level key, the level is also shown as an attribute
 	var ls string
+	var levelAttrs attributes
 	if h.opts.ReplaceAttr != nil {
 		a := h.opts.ReplaceAttr(nil, slog.Any(slog.LevelKey, r.Level))
-		ls = a.Value.String()
-		if a.Key != "level" {
-			r.AddAttrs(a)
+		ls = h.levelString(r.Level, a.Value)
+		if a.Key != slog.LevelKey && a.Key != "" && !h.opts.HideRenamedLevel {
+			levelAttrs = append(levelAttrs, a)
 		}
 	} else {
-		ls = r.Level.String()
-	}
-
-	var c color
-	lr := r.Level
-	switch {
-	case lr < 0:
-		c = h.getColor(h.opts.DebugColor)
-	case lr < 4:
-		c = h.getColor(h.opts.InfoColor)
-	case lr < 8:
-		c = h.getColor(h.opts.WarnColor)
-	default:
-		c = h.getColor(h.opts.ErrorColor)
+		ls = h.levelString(r.Level, slog.AnyValue(r.Level))
 	}
 
-	// Level with badge (same as normal mode)
-	b = append(b, h.colorStringBackgorund([]byte(" "+ls+" "), fgBlack, c.bg)...)
-	b = append(b, ' ')
+	c := h.levelColor(r.Level)
 
-	// Message (only if no newlines - otherwise add to multiline section)
-	messageHasNewlines := strings.Contains(r.Message, "\n")
-	if !messageHasNewlines {
-		b = append(b, []byte(r.Message)...)
+	b = h.appendLevelBadge(b, ls, c)
+
+	if h.opts.IndentSpans {
+		b = append(b, strings.Repeat("  ", h.spanDepth(ctx))...)
 	}
 
-	// Collect attributes
-	var as attributes
+	// Continuation lines of wrapped attributes are aligned with the message
+	hangingIndent := visibleLen(b[start:])
+	msgStart := len(b)
+
+	// Collect attributes, RecordStyle attributes only style the message
+	as := make(attributes, 0, r.NumAttrs()+len(levelAttrs))
+	var style Style
+	var styled bool
 	r.Attrs(func(a slog.Attr) bool {
-		a.Value = a.Value.Resolve()
-		as = append(as, a)
+		if s, ok := styleOf(a); ok {
+			style, styled = s, true
+			return true
+		}
+		if isProgress(a) {
+			return true
+		}
+		as = as.appendResolved(a)
 		return true
 	})
+	as = append(as, levelAttrs...)
+	if !styled {
+		style, styled = h.messageStyle(r.Level)
+	}
+
+	// Message (only if no newlines - otherwise add to multiline section)
+	msg := h.isolateBidi(r.Message)
+	messageHasNewlines := strings.Contains(msg, "\n") && !h.opts.EscapeNewlines
+	text := func(s string) []byte {
+		t := h.escapeNewlines([]byte(s))
+		if messageHasNewlines {
+			t = []byte(h.expandTabs(s))
+		}
+		if styled {
+			return h.styledText(t, style)
+		}
+		return t
+	}
+
+	var msgText []byte
+	if h.opts.MessageTemplates {
+		msgText, as = h.interpolateMessage(msg, as, text)
+	} else {
+		msgText = text(msg)
+	}
+	if !messageHasNewlines {
+		b = append(b, msgText...)
+	}
 
-	// Add pre-existing groups/attrs
+	as = h.withHandlerAttrs(as)
+
+	// Attributes of ContextWithAttrs are not part of groups
+	for _, a := range contextAttrs(ctx) {
+		as = as.appendResolved(a)
+	}
+
+	as = h.filterKeys(as, nil)
+	as = resolveValuers(as)
+	as = h.maskSecrets(as, nil)
+	as = h.redactAttrs(as)
+	as = h.truncateStrings(as)
+	as = h.isolateBidiAttrs(as)
+	as = h.pairDiffAttrs(as)
+	as = h.collapseGroups(as)
+	as = h.sortAttrs(as)
+
+	var errorAttrs attributes
+	if h.opts.ErrorBlock && !h.opts.EscapeNewlines {
+		as, errorAttrs = splitErrorAttrs(as)
+	}
+
+	// Separate inline and multiline attributes
+	inlineAttrs := make(attributes, 0, len(as))
+	var multilineAttrs attributes
+	for _, a := range as {
+		if h.opts.EscapeNewlines {
+			inlineAttrs = append(inlineAttrs, a)
+		} else if h.opts.Format == FormatExpanded {
+			multilineAttrs = append(multilineAttrs, a)
+		} else if h.attrContainsNewline(a) || h.isJSONValue(a.Value) || h.isMultilineJSON(a.Value) || h.isXMLValue(a.Value) || h.isFormattedSQL(a) || h.attrContainsStruct(a) || h.attrIsErrorTree(a) || h.attrIsHexDump(a) || !h.groupFitsInline(a) || h.groupAsTree(a) {
+			multilineAttrs = append(multilineAttrs, a)
+		} else {
+			inlineAttrs = append(inlineAttrs, a)
+		}
+	}
+
+	// Format inline attributes in logfmt on the same line
+	inlineAttrs, more := h.limitAttrs(inlineAttrs)
+	var cells []tableCell
+	if h.table != nil && more == 0 && !messageHasNewlines && len(multilineAttrs) == 0 {
+		cells = h.tableCells(nil, inlineAttrs, []string{})
+	}
+	if len(cells) > 0 {
+		b = h.formatTableRow(b, start, msgStart, cells)
+	} else {
+		// Records that can't be rendered as rows end the table
+		if h.table != nil {
+			h.table.reset()
+		}
+
+		// Attributes of consecutive records line up when messages are padded
+		if h.opts.MessagePadding > 0 && len(inlineAttrs) > 0 && !messageHasNewlines {
+			if w := visibleLen(b[msgStart:]); w < h.opts.MessagePadding {
+				b = append(b, strings.Repeat(" ", h.opts.MessagePadding-w)...)
+			}
+		}
+
+		b = h.formatInlineAttrs(b, inlineAttrs, c.fg, hangingIndent)
+		if more > 0 {
+			b = append(b, ' ')
+			b = append(b, h.faintedText([]byte("+"+strconv.Itoa(more)+" more"))...)
+		}
+	}
+	b = h.formatDeadline(b, ctx, r)
+
+	// If message or any attributes have newlines, format them in multiline section
+	if messageHasNewlines || len(multilineAttrs) > 0 {
+		// Add message if it has newlines
+		if messageHasNewlines {
+			b = append(b, "  "...)
+			b = append(b, msgText...)
+			b = append(b, '\n')
+		}
+
+		// Add multiline attributes, in FormatExpanded they start beneath the record line
+		if len(multilineAttrs) > 0 && h.opts.Format == FormatExpanded && !messageHasNewlines {
+			b = append(b, '\n')
+		}
+		if len(multilineAttrs) > 0 {
+			vi := newVisited()
+			b = h.colorize(b, multilineAttrs, 0, []string{}, vi)
+		}
+	}
+
+	b = h.appendErrorBlock(b, errorAttrs)
+	b = h.formatSourceSnippet(b, r)
+
+	if h.opts.NewLineAfterLog {
+		b = append(b, '\n')
+	}
+	b = append(b, '\n')
+
+	return b
+}
+
+// withHandlerAttrs adds the attributes of WithAttrs and nests as in the groups of WithGroup
+func (h *Handler) withHandlerAttrs(as attributes) attributes {
 	goas := h.goas
-	if r.NumAttrs() == 0 {
+	if len(as) == 0 {
 		for len(goas) > 0 && goas[len(goas)-1].group != "" {
 			goas = goas[:len(goas)-1]
 		}
 	}
 
-	for i := len(goas) - 1; i >= 0; i-- {
-		if goas[i].group != "" {
-			ng := slog.Attr{
-				Key:   goas[i].group,
-				Value: slog.GroupValue(as...),
-			}
-			as = attributes{ng}
-		} else {
-			as = append(as, goas[i].attrs...)
-		}
+	for i := len(goas) - 1; i >= 0; i-- {
+		if goas[i].group != "" {
+			ng := slog.Attr{
+				Key:   goas[i].group,
+				Value: slog.GroupValue(as...),
+			}
+			as = attributes{ng}
+		} else {
+			as = append(as, goas[i].attrs...)
+		}
+	}
+
+	return as
+}
+
+// formatInlineAttrs formats attributes in logfmt format, with WrapWidth they are packed onto continuation lines
+// indented by hangingIndent
+func (h *Handler) formatInlineAttrs(b []byte, as attributes, levelColor foregroundColor, hangingIndent int) []byte {
+	if width := h.wrapWidth(); width > 0 {
+		return h.formatInlineAttrsPacked(b, as, levelColor, hangingIndent, width)
+	}
+
+	start := len(b)
+	b = h.formatLogfmtAttrs(b, as, []string{}, levelColor)
+	return h.replaceAttrSeparator(b, start, " ")
+}
+
+// wrapWidth returns the width inline attributes are wrapped at, 0 when they aren't wrapped
+func (h *Handler) wrapWidth() int {
+	if h.opts.WrapWidth == 0 {
+		return int(h.out.width.Load())
+	}
+
+	return max(h.opts.WrapWidth, 0)
+}
+
+// replaceAttrSeparator replaces the AttrSeparator at b[i:] with sep,
+// the first attribute is separated from the message by a space
+func (h *Handler) replaceAttrSeparator(b []byte, i int, sep string) []byte {
+	if h.opts.AttrSeparator == sep || !bytes.HasPrefix(b[i:], []byte(h.opts.AttrSeparator)) {
+		return b
+	}
+
+	return slices.Replace(b, i, i+len(h.opts.AttrSeparator), []byte(sep)...)
+}
+
+// formatInlineAttrsPacked fills lines up to maxWidth with attributes, continuation lines are indented by hangingIndent
+func (h *Handler) formatInlineAttrsPacked(b []byte, as attributes, levelColor foregroundColor, hangingIndent int, maxWidth int) []byte {
+	if hangingIndent > maxWidth/2 {
+		hangingIndent = 4
+	}
+
+	width := visibleLen(b[bytes.LastIndexByte(b, '\n')+1:])
+	first := true
+	for _, a := range as {
+		seg := h.formatLogfmtAttrs(nil, attributes{a}, []string{}, levelColor)
+		if len(seg) == 0 {
+			continue
+		}
+		if first {
+			seg = h.replaceAttrSeparator(seg, 0, " ")
+			first = false
+		}
+
+		segWidth := visibleLen(seg)
+		if width+segWidth > maxWidth && width > hangingIndent {
+			b = append(b, '\n')
+			b = append(b, strings.Repeat(" ", hangingIndent)...)
+			width = hangingIndent
+
+			// Drop the separator
+			seg = h.replaceAttrSeparator(seg, 0, " ")[1:]
+			segWidth = visibleLen(seg)
+		}
+
+		b = append(b, seg...)
+		width += segWidth
+	}
+
+	return b
+}
+
+// limitAttrs returns the first MaxAttrs inline attributes and the number of the rest,
+// all attributes are returned when the handler logs Debug records
+func (h *Handler) limitAttrs(as attributes) (attributes, int) {
+	if h.opts.MaxAttrs <= 0 || len(as) <= h.opts.MaxAttrs || h.leveler().Level() <= slog.LevelDebug {
+		return as, 0
+	}
+
+	return as[:h.opts.MaxAttrs], len(as) - h.opts.MaxAttrs
+}
+
+// formatLogfmtAttrs formats attributes in logfmt format
+func (h *Handler) formatLogfmtAttrs(b []byte, as attributes, group []string, levelColor foregroundColor) []byte {
+	for _, a := range as {
+		if h.opts.ReplaceAttr != nil {
+			a = h.opts.ReplaceAttr(group, a)
+		}
+
+		// Handle groups by flattening with dot notation
+		if a.Value.Kind() == slog.KindGroup && !h.inlineGroups() {
+			newGroup := append(group, a.Key)
+			b = h.formatLogfmtAttrs(b, a.Value.Group(), newGroup, levelColor)
+			continue
+		}
+
+		b = append(b, h.opts.AttrSeparator...)
+
+		// Key (with group prefix if in a group), "key=" is colored together
+		b = h.appendCode(b, h.keyColor())
+		for _, g := range group {
+			b = append(b, g...)
+			b = append(b, '.')
+		}
+		b = append(b, a.Key...)
+		b = append(b, h.separator()...)
+		b = h.appendCode(b, resetColor)
+
+		b = h.appendLogfmtValue(b, a, group)
+	}
+
+	return b
+}
+
+// appendLogfmtValue appends the inline representation of the value of a
+func (h *Handler) appendLogfmtValue(b []byte, a slog.Attr, group []string) []byte {
+	if a.Value.Kind() == slog.KindGroup {
+		return append(b, h.formatInlineGroup(a.Value.Group(), append(group, a.Key))...)
+	}
+
+	// Primitives are appended directly, other values with detailed inline representation
+	if f := h.valueFormatter(group, a); f != nil {
+		return append(b, f(a.Value)...)
+	}
+
+	if h.isSQL(a) {
+		return append(b, h.escapeNewlines(h.appendSQL(nil, a.Value.String(), "", false))...)
+	}
+
+	if n, ok := a.Value.Any().(collapsedGroup); ok && a.Value.Kind() == slog.KindAny {
+		return append(b, h.formatCollapsedGroup(n)...)
 	}
 
-	if h.opts.SortKeys {
-		sort.Sort(as)
+	if pb, ok := h.appendHumanized(b, a.Key, a.Value); ok {
+		return pb
 	}
 
-	// Separate inline and multiline attributes
-	var inlineAttrs, multilineAttrs attributes
-	for _, a := range as {
-		if h.attrContainsNewline(a) || h.isJSON(a.Value.String()) || h.attrContainsStruct(a) {
-			multilineAttrs = append(multilineAttrs, a)
-		} else {
-			inlineAttrs = append(inlineAttrs, a)
-		}
+	if pb, ok := h.appendPrimitiveInline(b, a.Value); ok {
+		return pb
 	}
 
-	// Format inline attributes in logfmt on the same line
-	b = h.formatLogfmtAttrs(b, inlineAttrs, []string{}, c.fg)
+	return append(b, h.escapeNewlines(h.formatValueInline(a, group))...)
+}
 
-	// If message or any attributes have newlines, format them in multiline section
-	if messageHasNewlines || len(multilineAttrs) > 0 {
-		// Add message if it has newlines
-		if messageHasNewlines {
-			b = append(b, "  "...)
-			b = append(b, []byte(r.Message)...)
-			b = append(b, '\n')
-		}
+// inlineGroups reports if groups are rendered inline as g={a=1 b=2} instead of flattened with dot notation
+func (h *Handler) inlineGroups() bool {
+	return h.opts.InlineGroupMaxAttrs > 0 || h.opts.InlineGroupMaxWidth > 0
+}
 
-		// Add multiline attributes
-		if len(multilineAttrs) > 0 {
-			vi := make(visited)
-			b = h.colorize(b, multilineAttrs, 0, []string{}, vi)
-		}
+// groupAsTree reports whether a is a group rendered as an indented tree in the multiline section
+func (h *Handler) groupAsTree(a slog.Attr) bool {
+	return h.opts.GroupTree && a.Value.Kind() == slog.KindGroup && len(a.Value.Group()) > 0
+}
+
+// groupFitsInline checks the group against InlineGroupMaxAttrs and InlineGroupMaxWidth
+func (h *Handler) groupFitsInline(a slog.Attr) bool {
+	if a.Value.Kind() != slog.KindGroup || !h.inlineGroups() {
+		return true
 	}
 
-	if h.opts.NewLineAfterLog {
-		b = append(b, '\n')
+	ga := a.Value.Group()
+	if h.opts.InlineGroupMaxAttrs > 0 && len(ga) > h.opts.InlineGroupMaxAttrs {
+		return false
 	}
-	b = append(b, '\n')
 
-	return b
+	if h.opts.InlineGroupMaxWidth > 0 {
+		w := len(a.Key) + 1 + visibleLen(h.formatInlineGroup(ga, []string{a.Key}))
+		if w > h.opts.InlineGroupMaxWidth {
+			return false
+		}
+	}
+
+	return true
 }
 
-// formatLogfmtAttrs formats attributes in logfmt format
-func (h *developHandler) formatLogfmtAttrs(b []byte, as attributes, group []string, levelColor foregroundColor) []byte {
-	for _, a := range as {
+// formatInlineGroup formats group attributes as {a=1 b=2}
+func (h *Handler) formatInlineGroup(as []slog.Attr, group []string) []byte {
+	b := []byte{'{'}
+	for i, a := range as {
 		if h.opts.ReplaceAttr != nil {
 			a = h.opts.ReplaceAttr(group, a)
 		}
 
-		// Handle groups by flattening with dot notation
+		if i > 0 {
+			b = append(b, h.opts.AttrSeparator...)
+		}
+
+		b = append(b, h.colorString([]byte(a.Key+h.separator()), h.keyColor())...)
 		if a.Value.Kind() == slog.KindGroup {
-			newGroup := append(group, a.Key)
-			b = h.formatLogfmtAttrs(b, a.Value.Group(), newGroup, levelColor)
-			continue
+			b = append(b, h.formatInlineGroup(a.Value.Group(), append(group, a.Key))...)
+		} else if f := h.valueFormatter(group, a); f != nil {
+			b = append(b, f(a.Value)...)
+		} else if h.isSQL(a) {
+			b = append(b, h.escapeNewlines(h.appendSQL(nil, a.Value.String(), "", false))...)
+		} else if hb, ok := h.appendHumanized(b, a.Key, a.Value); ok {
+			b = hb
+		} else {
+			b = append(b, h.escapeNewlines(h.formatValueInline(a, group))...)
 		}
+	}
 
-		b = append(b, ' ')
+	return append(b, '}')
+}
 
-		// Key (with group prefix if in a group)
-		key := a.Key
-		if len(group) > 0 {
-			key = strings.Join(append(group, a.Key), ".")
+// appendPrimitiveInline appends numbers, bools and plain strings without intermediate allocations,
+// it reports false for values which need formatValueInline
+func (h *Handler) appendPrimitiveInline(b []byte, v slog.Value) ([]byte, bool) {
+	switch v.Kind() {
+	case slog.KindInt64, slog.KindUint64, slog.KindFloat64:
+		b = h.appendCode(b, h.numberColor())
+	case slog.KindBool:
+		if v.Bool() {
+			b = h.appendCode(b, fgGreen)
+		} else {
+			b = h.appendCode(b, fgRed)
+		}
+	case slog.KindString:
+		s := v.String()
+		if strings.ContainsAny(s, "\r\n") || h.isJSON(s) || h.isURL([]byte(s)) {
+			return b, false
 		}
-		// Color the "key=" together
-		b = append(b, h.colorString([]byte(key+"="), fgGray)...)
 
-		// Format value with detailed inline representation
-		val := h.formatValueInline(a)
-		b = append(b, val...)
+		if c := h.stringColor(nil); c != nil {
+			return h.appendColored(b, h.appendQuoted(nil, s), c), true
+		}
+		return h.appendQuoted(b, s), true
+	default:
+		return b, false
 	}
 
-	return b
+	b = appendValue(b, v)
+	return h.appendCode(b, resetColor), true
 }
 
 // formatLogfmtValue formats a value for logfmt, quoting if necessary
-func (h *developHandler) formatLogfmtValue(val []byte, color foregroundColor) []byte {
+func (h *Handler) formatLogfmtValue(val []byte, color foregroundColor) []byte {
 	if color != nil {
 		return h.colorString(val, color)
 	}
 	return val
 }
 
-func (h *developHandler) formatSourceInfo(b []byte, r *slog.Record) []byte {
+func (h *Handler) formatSourceInfo(b []byte, r *slog.Record) []byte {
 	if h.opts.AddSource {
 		f, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
 		s := &slog.Source{
@@ -452,9 +1395,9 @@ func (h *developHandler) formatSourceInfo(b []byte, r *slog.Record) []byte {
 		b = append(b, ' ')
 
 		if h.opts.SameSourceInfoColor {
-			b = append(b, h.underlineText(h.colorStringFainted(append(append([]byte(s.File), ':'), []byte(strconv.Itoa(s.Line))...), fgWhite))...)
+			b = append(b, h.underlineText(h.colorStringFainted(append(append([]byte(s.File), ':'), []byte(strconv.Itoa(s.Line))...), h.sourceColor()))...)
 		} else {
-			b = append(b, h.underlineText(h.colorStringFainted([]byte(s.File), fgWhite))...)
+			b = append(b, h.underlineText(h.colorStringFainted([]byte(s.File), h.sourceColor()))...)
 			b = append(b, h.faintedText([]byte(":"))...)
 			b = append(b, h.colorStringFainted([]byte(strconv.Itoa(s.Line)), fgRed)...)
 		}
@@ -465,33 +1408,18 @@ func (h *developHandler) formatSourceInfo(b []byte, r *slog.Record) []byte {
 	return b
 }
 
-func (h *developHandler) levelMessage(b []byte, r *slog.Record) []byte {
+func (h *Handler) levelMessage(b []byte, r *slog.Record) []byte {
 	var ls string
 	if h.opts.ReplaceAttr != nil {
 		a := h.opts.ReplaceAttr(nil, slog.Any(slog.LevelKey, r.Level))
-		ls = a.Value.String()
-		if a.Key != "level" {
-			r.AddAttrs(a)
-		}
+		ls = h.levelString(r.Level, a.Value)
 	} else {
-		ls = r.Level.String()
-	}
-
-	var c color
-	lr := r.Level
-	switch {
-	case lr < 0:
-		c = h.getColor(h.opts.DebugColor)
-	case lr < 4:
-		c = h.getColor(h.opts.InfoColor)
-	case lr < 8:
-		c = h.getColor(h.opts.WarnColor)
-	default:
-		c = h.getColor(h.opts.ErrorColor)
+		ls = h.levelString(r.Level, slog.AnyValue(r.Level))
 	}
 
-	b = append(b, h.colorStringBackgorund([]byte(" "+ls+" "), fgBlack, c.bg)...)
-	b = append(b, ' ')
+	c := h.levelColor(r.Level)
+
+	b = h.appendLevelBadge(b, ls, c)
 	b = append(b, h.colorString([]byte(r.Message), c.fg)...)
 	b = append(b, '\n')
 
@@ -503,20 +1431,40 @@ type visitKey struct {
 	typ reflect.Type
 }
 
-type visited map[visitKey]struct{}
+// visited tracks pointers already rendered and the nesting depth, visible bytes and key path of the value being rendered
+type visited struct {
+	seen  map[visitKey]struct{}
+	depth int
+	size  int
+	path  []string
+}
 
-func (h *developHandler) colorize(b []byte, as attributes, l int, group []string, vi visited) []byte {
-	if h.opts.SortKeys {
-		sort.Sort(as)
-	}
+func newVisited() *visited {
+	return &visited{seen: map[visitKey]struct{}{}}
+}
+
+// push adds the field, map key or index of the element being rendered to the path
+func (vi *visited) push(name string) {
+	vi.path = append(vi.path, name)
+}
+
+func (vi *visited) pop() {
+	vi.path = vi.path[:len(vi.path)-1]
+}
+
+func (h *Handler) colorize(b []byte, as attributes, l int, group []string, vi *visited) []byte {
+	as = h.sortAttrs(as)
 
 	paddingNoColor := h.padding(as, group, nil, h.colorString)
 	for _, a := range as {
+		vi.size = 0
+
 		if h.opts.ReplaceAttr != nil {
 			a = h.opts.ReplaceAttr(group, a)
 		}
+		vi.path = append(group[:len(group):len(group)], a.Key)
 
-		key := h.colorString([]byte(a.Key), fgGray)
+		key := h.colorString([]byte(a.Key), h.keyColor())
 		val := []byte(a.Value.String())
 		valOld := val
 		vs := val
@@ -524,53 +1472,143 @@ func (h *developHandler) colorize(b []byte, as attributes, l int, group []string
 
 		switch a.Value.Kind() {
 		case slog.KindFloat64, slog.KindInt64, slog.KindUint64:
-			mark = h.colorString([]byte("#"), fgCyan)
-			val = h.colorString(val, fgCyan)
+			mark = h.marker(h.opts.Markers.Number, "#", fgCyan)
+			if hv, ok := h.appendHumanized(nil, a.Key, a.Value); ok {
+				val = hv
+			} else {
+				val = h.colorString(val, h.numberColor())
+			}
 		case slog.KindBool:
 			c := fgRed
 			if a.Value.Bool() {
 				c = fgGreen
 			}
 
-			mark = h.colorString([]byte("#"), c)
+			mark = h.marker(h.opts.Markers.Bool, "#", c)
 			val = h.colorString(val, c)
 		case slog.KindString:
 			if len(val) == 0 {
 				val = h.colorStringFainted([]byte("empty"), fgWhite)
 			} else if h.isJSON(string(val)) {
 				// Format as colorized JSON
-				mark = h.colorString([]byte("J"), fgWhite)
-				val = h.formatJSONMultiline(string(val), l)
+				mark = h.marker(h.opts.Markers.JSON, "J", fgWhite)
+				if table, ok := h.formatJSONTable(string(val), l); ok {
+					val = table
+				} else {
+					val = h.formatJSONMultiline(string(val), l)
+				}
+			} else if h.isSQL(a) {
+				mark = h.marker(h.opts.Markers.SQL, "Q", fgMagenta)
+				indent := strings.Repeat(" ", l*2+2)
+				val = append([]byte("\n"+indent), h.appendSQL(nil, strings.TrimSpace(string(val)), indent, h.opts.FormatSQL)...)
+			} else if isXML(string(val)) {
+				mark = h.marker(h.opts.Markers.XML, "X", fgWhite)
+				val = h.formatXML(string(val), strings.Repeat(" ", l*2+2))
 			} else if h.isURL(val) {
-				mark = h.colorString([]byte("*"), fgCyan)
-				val = h.underlineText(h.colorString(val, fgCyan))
+				mark = h.marker(h.opts.Markers.URL, "*", fgCyan)
+				val = h.hyperlink(h.underlineText(h.colorString(val, h.urlColor())), urlLink(string(val)))
+			} else if isUnifiedDiff(string(val)) {
+				indent := ""
+				if h.opts.StringIndentation {
+					indent = strings.Repeat(" ", l*2+(4+(paddingNoColor)))
+				}
+				val = h.formatUnifiedDiff(h.expandTabs(string(val)), indent)
+			} else if isYAML(string(val)) {
+				mark = h.marker(h.opts.Markers.YAML, "Y", fgWhite)
+				val = h.formatYAML(string(val), strings.Repeat(" ", l*2+2))
 			} else {
+				val = []byte(h.expandTabs(string(val)))
 				if h.opts.StringIndentation {
 					count := l*2 + (4 + (paddingNoColor))
 					val = []byte(strings.ReplaceAll(string(val), "\n", "\n"+strings.Repeat(" ", count)))
 				}
+				val = h.formatLogfmtValue(val, h.stringColor(nil))
 			}
-		case slog.KindTime, slog.KindDuration:
-			mark = h.colorString([]byte("@"), fgWhite)
+		case slog.KindTime:
+			mark = h.marker(h.opts.Markers.Time, "@", fgWhite)
 			val = h.colorString(val, fgWhite)
+		case slog.KindDuration:
+			c, _ := h.durationColor(a.Key, a.Value.Duration())
+			mark = h.marker(h.opts.Markers.Time, "@", fgWhite)
+			val = h.colorString(val, c)
 		case slog.KindAny:
 			av := a.Value.Any()
+			if tb, ok := h.formatRegisteredType(reflect.ValueOf(av)); ok {
+				val = tb
+				break
+			}
+
 			if err, ok := av.(error); ok {
-				mark = h.colorString([]byte("E"), fgRed)
+				mark = h.marker(h.opts.Markers.Error, "E", fgRed)
+				if h.isErrorTree(err) {
+					val = h.formatErrorTree(err, l*2+2)
+					break
+				}
 				// Always use inline format for errors
 				val = h.formatError(err)
 				break
 			}
 
 			if t, ok := av.(*time.Time); ok {
-				mark = h.colorString([]byte("@"), fgWhite)
+				mark = h.marker(h.opts.Markers.Time, "@", fgWhite)
 				val = h.colorString([]byte(t.String()), fgWhite)
 				break
 			}
 
+			if _, ok := av.(sqlNull); ok {
+				val = h.nullText()
+				break
+			}
+
+			if js, ok := h.jsonText(av, vi.path); ok {
+				mark = h.marker(h.opts.Markers.JSON, "J", fgWhite)
+				if table, ok := h.formatJSONTable(js, l); ok {
+					val = table
+				} else {
+					val = h.formatJSONMultiline(js, l)
+				}
+				break
+			}
+
+			if n, ok := av.(collapsedGroup); ok {
+				mark = h.marker(h.opts.Markers.Group, "G", fgGreen)
+				val = h.formatCollapsedGroup(n)
+				break
+			}
+
+			if e, ok := av.(Elapsed); ok {
+				mark = h.marker(h.opts.Markers.Time, "@", fgWhite)
+				val = h.colorString([]byte(e.String()), h.elapsedColor(a.Key, e))
+				break
+			}
+
+			if hv, ok := h.formatHTTP(av, vi.path); ok {
+				mark = h.marker(h.opts.Markers.HTTP, "H", fgCyan)
+				val = hv
+				break
+			}
+
+			if d, ok := av.(DiffValue); ok {
+				mark = h.marker(h.opts.Markers.Diff, "D", fgYellow)
+				val = h.formatDiff(d, l)
+				break
+			}
+
 			if d, ok := av.(*time.Duration); ok {
-				mark = h.colorString([]byte("@"), fgWhite)
-				val = h.colorString([]byte(d.String()), fgWhite)
+				c, _ := h.durationColor(a.Key, *d)
+				mark = h.marker(h.opts.Markers.Time, "@", fgWhite)
+				val = h.colorString([]byte(d.String()), c)
+				break
+			}
+
+			if d, ok := h.hexDumpBytes(av); ok {
+				mark = h.marker(h.opts.Markers.Bytes, "B", fgCyan)
+				val = h.formatHexDump(d, strings.Repeat(" ", l*2+2))
+				break
+			}
+
+			if tb, ok := h.appendText(av); ok {
+				val = tb
 				break
 			}
 
@@ -581,7 +1619,7 @@ func (h *developHandler) colorize(b []byte, as attributes, l int, group []string
 
 			if h.opts.StringerFormatter {
 				if stringer, ok := av.(fmt.Stringer); ok {
-					val = []byte(stringer.String())
+					val = h.stringerText(stringer)
 					break
 				}
 			}
@@ -589,76 +1627,88 @@ func (h *developHandler) colorize(b []byte, as attributes, l int, group []string
 			avt := reflect.TypeOf(av)
 			avv := reflect.ValueOf(av)
 			if avt == nil {
-				mark = h.colorString([]byte("!"), fgRed)
+				mark = h.marker(h.opts.Markers.Nil, "!", fgRed)
 				val = h.nilString()
 				break
 			}
 
 			ut, uv, ptrs := h.reducePointerTypeValue(avt, avv)
-			val = bytes.Repeat(h.colorString([]byte("*"), fgRed), ptrs)
+			val = h.pointerMarks(ptrs)
 
 			switch ut.Kind() {
 			case reflect.Array:
-				mark = h.colorString([]byte("A"), fgGreen)
+				mark = h.marker(h.opts.Markers.Array, "A", fgGreen)
 				val = h.formatSlice(avt, avv, vi)
 			case reflect.Slice:
-				mark = h.colorString([]byte("S"), fgGreen)
+				mark = h.marker(h.opts.Markers.Slice, "S", fgGreen)
 				val = h.formatSlice(avt, avv, vi)
 			case reflect.Map:
-				mark = h.colorString([]byte("M"), fgGreen)
+				mark = h.marker(h.opts.Markers.Map, "M", fgGreen)
 				val = h.formatMap(avt, avv, vi)
 			case reflect.Struct:
-				mark = h.colorString([]byte("S"), fgYellow)
+				mark = h.marker(h.opts.Markers.Struct, "S", fgYellow)
 				val = h.formatStruct(avt, avv, l, vi)
 			case reflect.Float32, reflect.Float64:
-				mark = h.colorString([]byte("#"), fgCyan)
-				vs = atb(uv.Float())
-				val = append(val, h.colorString(vs, fgCyan)...)
+				mark = h.marker(h.opts.Markers.Number, "#", fgCyan)
+				vs = strconv.AppendFloat(nil, uv.Float(), 'g', -1, 64)
+				val = append(val, h.colorString(vs, h.numberColor())...)
 			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-				mark = h.colorString([]byte("#"), fgCyan)
-				vs = atb(uv.Int())
-				val = append(val, h.colorString(vs, fgCyan)...)
+				mark = h.marker(h.opts.Markers.Number, "#", fgCyan)
+				vs = strconv.AppendInt(nil, uv.Int(), 10)
+				val = append(val, h.colorString(vs, h.numberColor())...)
 			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-				mark = h.colorString([]byte("#"), fgCyan)
-				vs = atb(uv.Uint())
-				val = append(val, h.colorString(vs, fgCyan)...)
+				mark = h.marker(h.opts.Markers.Number, "#", fgCyan)
+				vs = strconv.AppendUint(nil, uv.Uint(), 10)
+				val = append(val, h.colorString(vs, h.numberColor())...)
 			case reflect.Bool:
 				c := fgRed
 				if uv.Bool() {
 					c = fgGreen
 				}
 
-				mark = h.colorString([]byte("#"), c)
-				vs = atb(uv.Bool())
+				mark = h.marker(h.opts.Markers.Bool, "#", c)
+				vs = strconv.AppendBool(nil, uv.Bool())
 				val = append(val, h.colorString(vs, c)...)
 			case reflect.String:
 				s := uv.String()
 				if len(s) == 0 {
 					val = h.colorStringFainted([]byte("empty"), fgWhite)
 				} else if h.isURL([]byte(s)) {
-					val = h.underlineText(h.colorString(val, fgCyan))
+					val = h.hyperlink(h.underlineText(h.colorString(val, h.urlColor())), urlLink(s))
 				} else {
-					val = []byte(uv.String())
+					val = h.formatLogfmtValue([]byte(uv.String()), h.stringColor(nil))
 				}
 			default:
-				mark = h.colorString([]byte("!"), fgRed)
-				val = h.colorString(atb("Unknown type"), fgRed)
+				mark = h.marker(h.opts.Markers.Nil, "!", fgRed)
+				val = h.colorString([]byte("Unknown type"), fgRed)
 			}
 		case slog.KindGroup:
-			mark = h.colorString([]byte("G"), fgGreen)
+			mark = h.marker(h.opts.Markers.Group, "G", fgGreen)
 			var ga attributes
 			ga = a.Value.Group()
-			group = append(group, a.Key)
 
+			// Siblings after the group keep the parent group
 			val = []byte("\n")
-			val = append(val, h.colorize(nil, ga, l+1, group, vi)...)
+			val = append(val, h.colorize(nil, ga, l+1, append(slices.Clip(group), a.Key), vi)...)
+		}
+
+		if f := h.valueFormatter(group, a); f != nil {
+			mark = []byte{}
+			val = f(a.Value)
+		}
+
+		// Keys are aligned in FormatExpanded, also those without a mark
+		if len(mark) == 0 && h.opts.Format == FormatExpanded {
+			mark = []byte{' '}
 		}
 
 		b = append(b, bytes.Repeat([]byte(" "), l*2)...)
 		b = append(b, mark...)
 		b = append(b, ' ')
 		b = append(b, key...)
-		// Only add padding for alignment when not in OneLineFormat mode
+		if h.opts.Format == FormatExpanded {
+			b = append(b, bytes.Repeat([]byte(" "), max(paddingNoColor-displayWidth(a.Key), 0))...)
+		}
 
 		b = append(b, []byte(h.separator())...)
 		b = append(b, val...)
@@ -679,20 +1729,20 @@ func (h *developHandler) colorize(b []byte, as attributes, l int, group []string
 	return b
 }
 
-func (h *developHandler) separator() string {
-	return "="
+func (h *Handler) separator() string {
+	return h.opts.KeyValueSeparator
 }
 
-func (h *developHandler) padding(a attributes, g []string, color foregroundColor, colorFunction func(b []byte, fgColor foregroundColor) []byte) int {
+func (h *Handler) padding(a attributes, g []string, color foregroundColor, colorFunction func(b []byte, fgColor foregroundColor) []byte) int {
 	var padding int
 	for _, attr := range a {
 		if h.opts.ReplaceAttr != nil {
 			attr = h.opts.ReplaceAttr(g, attr)
 		}
 
-		colorLength := len(attr.Key)
+		colorLength := displayWidth(attr.Key)
 		if color != nil {
-			colorLength = len(colorFunction([]byte(attr.Key), color))
+			colorLength += len(colorFunction([]byte(attr.Key), color)) - len(attr.Key)
 		}
 
 		if colorLength > padding {
@@ -703,12 +1753,25 @@ func (h *developHandler) padding(a attributes, g []string, color foregroundColor
 	return padding
 }
 
-func (h *developHandler) isURL(u []byte) bool {
+func (h *Handler) isURL(u []byte) bool {
+	// Request URIs are either absolute paths or have a scheme
+	if len(u) == 0 || (u[0] != '/' && bytes.IndexByte(u, ':') < 0) {
+		return false
+	}
+
 	_, err := url.ParseRequestURI(string(u))
 	return err == nil
 }
 
-func (h *developHandler) formatError(err error) []byte {
+func (h *Handler) formatError(err error) (b []byte) {
+	defer h.recoverFormatter(&b)
+
+	result := strings.Join(errorMessages(err), ": ")
+	return h.colorString([]byte(result), fgRed)
+}
+
+// errorMessages returns the messages of err and its wrapped errors, outermost first
+func errorMessages(err error) []string {
 	var parts []string
 
 	// Collect all error messages
@@ -719,9 +1782,6 @@ func (h *developHandler) formatError(err error) []byte {
 		}
 
 		// Try to unwrap multiple errors (errors.Join)
-		type unwrapMultiple interface {
-			Unwrap() []error
-		}
 		if e, ok := err.(unwrapMultiple); ok {
 			errs := e.Unwrap()
 			for _, inner := range errs {
@@ -747,72 +1807,122 @@ func (h *developHandler) formatError(err error) []byte {
 	}
 
 	collectErrors(err)
-	result := strings.Join(parts, ": ")
-	return h.colorString([]byte(result), fgRed)
+	return parts
 }
 
-func (h *developHandler) formatSlice(st reflect.Type, sv reflect.Value, vi visited) []byte {
-	ts := h.buildTypeString(st.String())
+func (h *Handler) formatSlice(st reflect.Type, sv reflect.Value, vi *visited) (b []byte) {
+	defer h.recoverFormatter(&b)
+
 	_, sv, _ = h.reducePointerTypeValue(st, sv)
+	if sv.Kind() == reflect.Slice && sv.Type().Elem().Kind() == reflect.Uint8 {
+		return h.formatBytes(sv.Bytes())
+	}
+	ts := h.buildTypeString(st.String())
 
-	b := h.colorString([]byte(strconv.Itoa(sv.Len())), fgCyan)
+	b = h.colorString([]byte(strconv.Itoa(sv.Len())), fgCyan)
 	b = append(b, ' ')
 	b = append(b, ts...)
 	b = append(b, h.colorString([]byte("{"), fgGreen)...)
 
-	maxItems := min(int(h.opts.MaxSlicePrintSize), sv.Len())
+	if !h.enterValue(vi) {
+		b = h.appendCutOff(b, 0)
+		return append(b, h.colorString([]byte("}"), fgGreen)...)
+	}
+	defer h.leaveValue(vi)
+	vi.size += visibleLen(b)
+
+	maxItems := min(printLimit(h.opts.MaxSlicePrintSize), sv.Len())
 	for i := 0; i < maxItems; i++ {
 		if i > 0 {
 			b = append(b, ' ')
+			vi.size++
+		}
+		if h.overBudget(vi) {
+			b = h.appendCutOff(b, sv.Len()-i)
+			return append(b, h.colorString([]byte("}"), fgGreen)...)
 		}
 		v := sv.Index(i)
-		b = append(b, h.elementType(v.Type(), v, 0, 0, vi)...)
+		vi.push(strconv.Itoa(i))
+		b = h.appendElement(b, v.Type(), v, 0, 0, vi)
+		vi.pop()
 	}
 	if sv.Len() > maxItems {
-		b = append(b, ' ')
+		if maxItems > 0 {
+			b = append(b, ' ')
+		}
 		b = append(b, h.colorString([]byte("..."), fgCyan)...)
 	}
 	b = append(b, h.colorString([]byte("}"), fgGreen)...)
 	return b
 }
 
-func (h *developHandler) formatMap(st reflect.Type, sv reflect.Value, vi visited) []byte {
+func (h *Handler) formatMap(st reflect.Type, sv reflect.Value, vi *visited) (b []byte) {
+	defer h.recoverFormatter(&b)
+
 	ts := h.buildTypeString(st.String())
 	_, sv, _ = h.reducePointerTypeValue(st, sv)
 
-	b := h.colorString([]byte(strconv.Itoa(sv.Len())), fgCyan)
+	b = h.colorString([]byte(strconv.Itoa(sv.Len())), fgCyan)
 	b = append(b, ' ')
 	b = append(b, ts...)
 	b = append(b, h.colorString([]byte("{"), fgGreen)...)
 
+	if !h.enterValue(vi) {
+		b = h.appendCutOff(b, 0)
+		return append(b, h.colorString([]byte("}"), fgGreen)...)
+	}
+	defer h.leaveValue(vi)
+	vi.size += visibleLen(b)
+
 	sk := h.sortMapKeys(sv)
-	for i, k := range sk {
+	maxItems := min(printLimit(h.opts.MaxMapPrintSize), len(sk))
+	cut := false
+	for i, k := range sk[:maxItems] {
 		if i > 0 {
 			b = append(b, ' ')
+			vi.size++
+		}
+		if h.overBudget(vi) {
+			b = h.appendCutOff(b, len(sk)-i)
+			cut = true
+			break
 		}
 		v := sv.MapIndex(k)
 		v = h.reducePointerValue(v)
 		k = h.reducePointerValue(k)
 
-		b = append(b, h.colorString(atb(k.Interface()), fgGreen)...)
+		kb := atb(k.Interface())
+		b = append(b, h.colorString(kb, fgGreen)...)
 		b = append(b, '=')
-		b = append(b, h.elementType(v.Type(), v, 0, 0, vi)...)
+		vi.size += displayWidth(string(kb)) + 1
+		if r := h.redactedField(string(kb)); r != nil {
+			b = append(b, r...)
+			continue
+		}
+		vi.push(string(kb))
+		b = h.appendElement(b, v.Type(), v, 0, 0, vi)
+		vi.pop()
+	}
+	if len(sk) > maxItems && !cut {
+		if maxItems > 0 {
+			b = append(b, ' ')
+		}
+		b = append(b, h.colorString([]byte("..."), fgCyan)...)
+		if maxItems > 0 {
+			b = append(b, h.faintedText([]byte(" (+"+strconv.Itoa(len(sk)-maxItems)+" more)"))...)
+		}
 	}
 	b = append(b, h.colorString([]byte("}"), fgGreen)...)
 	return b
 }
 
-func (h *developHandler) structKeyPadding(sv reflect.Value, fgColor *foregroundColor) int {
-	st := sv.Type()
+func (h *Handler) structKeyPadding(sv reflect.Value, fgColor *foregroundColor) int {
 	p := 0
-	for i := 0; i < sv.NumField(); i++ {
-		if !st.Field(i).IsExported() {
-			continue
-		}
-
-		c := len(st.Field(i).Name)
+	for _, f := range structFields(sv) {
+		name := f.name
+		c := displayWidth(name)
 		if fgColor != nil {
-			c = len(h.colorString([]byte(st.Field(i).Name), *fgColor))
+			c += len(h.colorString([]byte(name), *fgColor)) - len(name)
 		}
 
 		if c > p {
@@ -823,44 +1933,120 @@ func (h *developHandler) structKeyPadding(sv reflect.Value, fgColor *foregroundC
 	return p
 }
 
-func (h *developHandler) formatStruct(st reflect.Type, sv reflect.Value, l int, vi visited) []byte {
-	b := h.buildTypeString(st.String())
-	_, sv, _ = h.reducePointerTypeValue(st, sv)
+func (h *Handler) formatStruct(st reflect.Type, sv reflect.Value, l int, vi *visited) (b []byte) {
+	defer h.recoverFormatter(&b)
 
-	pc := h.structKeyPadding(sv, &fgGreen)
-	pr := h.structKeyPadding(sv, nil)
+	b = h.buildTypeString(st.String())
+	_, sv, _ = h.reducePointerTypeValue(st, sv)
 
-	for i := 0; i < sv.NumField(); i++ {
-		if !sv.Type().Field(i).IsExported() {
-			continue
-		}
+	if !h.enterValue(vi) {
+		b = append(b, h.colorString([]byte("{"), h.punctColor(fgYellow))...)
+		b = h.appendCutOff(b, 0)
+		return append(b, h.colorString([]byte("}"), h.punctColor(fgYellow))...)
+	}
+	defer h.leaveValue(vi)
+	vi.size += visibleLen(b)
 
-		v := sv.Field(i)
-		t := v.Type()
+	pr := h.structKeyPadding(sv, nil)
 
-		tb := h.colorString([]byte(sv.Type().Field(i).Name), fgGreen)
+	fields := structFields(sv)
+	for i, f := range fields {
 		b = append(b, '\n')
 		b = append(b, bytes.Repeat([]byte(" "), l*2+4)...)
-		b = append(b, tb...)
-		b = append(b, bytes.Repeat([]byte(" "), pc-len(tb))...)
+		if h.overBudget(vi) {
+			b = h.appendCutOff(b, len(fields)-i)
+			break
+		}
+		b = append(b, h.colorString([]byte(f.name), fgGreen)...)
+		b = append(b, bytes.Repeat([]byte(" "), pr-displayWidth(f.name))...)
 		b = append(b, ':')
 		b = append(b, ' ')
-		b = append(b, h.elementType(t, v, l, l*2+pr+2, vi)...)
+		vi.size += 1 + l*2 + 4 + pr + 2
+		if r := h.fieldValueRedacted(f); r != nil {
+			b = append(b, r...)
+			continue
+		}
+		vi.push(f.name)
+		b = h.appendElement(b, f.value.Type(), f.value, l, l*2+pr+2, vi)
+		vi.pop()
+	}
+
+	return b
+}
+
+// formatStructInline formats exported struct fields on one line as Type{Field=value Field=value}
+func (h *Handler) formatStructInline(st reflect.Type, sv reflect.Value, vi *visited) (b []byte) {
+	defer h.recoverFormatter(&b)
+
+	b = h.buildTypeString(st.String())
+	_, sv, _ = h.reducePointerTypeValue(st, sv)
+
+	b = append(b, h.colorString([]byte("{"), h.punctColor(fgYellow))...)
+
+	if !h.enterValue(vi) {
+		b = h.appendCutOff(b, 0)
+		return append(b, h.colorString([]byte("}"), h.punctColor(fgYellow))...)
+	}
+	defer h.leaveValue(vi)
+	vi.size += visibleLen(b)
+
+	fields := structFields(sv)
+	for i, f := range fields {
+		if i > 0 {
+			b = append(b, ' ')
+			vi.size++
+		}
+		if h.overBudget(vi) {
+			b = h.appendCutOff(b, len(fields)-i)
+			break
+		}
+
+		b = append(b, h.colorString([]byte(f.name), fgGreen)...)
+		b = append(b, '=')
+		vi.size += displayWidth(f.name) + 1
+		if r := h.fieldValueRedacted(f); r != nil {
+			b = append(b, r...)
+			continue
+		}
+		vi.push(f.name)
+		b = h.appendElement(b, f.value.Type(), f.value, 0, 0, vi)
+		vi.pop()
 	}
+	b = append(b, h.colorString([]byte("}"), h.punctColor(fgYellow))...)
 
 	return b
 }
 
 var marshalTextInterface = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
 
-func (h *developHandler) elementType(t reflect.Type, v reflect.Value, l int, p int, vi visited) []byte {
+func (h *Handler) elementType(t reflect.Type, v reflect.Value, l int, p int, vi *visited) []byte {
+	if tb, ok := h.formatRegisteredType(v); ok {
+		return tb
+	}
+
+	if v.CanInterface() {
+		if dv, ok := driverValue(v.Interface()); ok {
+			if _, null := dv.Any().(sqlNull); null {
+				return h.nullText()
+			}
+			v = reflect.ValueOf(dv.Any())
+			return h.elementType(v.Type(), v, l, p, vi)
+		}
+	}
+
+	if v.CanInterface() {
+		if tb, ok := h.appendText(v.Interface()); ok {
+			return tb
+		}
+	}
+
 	if t.Implements(marshalTextInterface) {
 		return atb(v)
 	}
 
 	if h.opts.StringerFormatter {
 		if stringer, ok := v.Interface().(fmt.Stringer); ok {
-			return []byte(stringer.String())
+			return h.stringerText(stringer)
 		}
 	}
 
@@ -870,6 +2056,9 @@ func (h *developHandler) elementType(t reflect.Type, v reflect.Value, l int, p i
 	case reflect.Map:
 		return h.formatMap(t, v, vi)
 	case reflect.Struct:
+		if h.opts.EscapeNewlines {
+			return h.formatStructInline(t, v, vi)
+		}
 		return h.formatStruct(t, v, l+1, vi)
 	case reflect.Pointer:
 		key := visitKey{
@@ -878,31 +2067,31 @@ func (h *developHandler) elementType(t reflect.Type, v reflect.Value, l int, p i
 		}
 		if v.IsNil() {
 			return h.nilString()
-		} else if _, ok := vi[key]; ok {
+		} else if _, ok := vi.seen[key]; ok {
 			return atb(v)
 		} else {
-			vi[key] = struct{}{}
+			vi.seen[key] = struct{}{}
 			return h.elementType(t, v.Elem(), l, p, vi)
 		}
 	case reflect.Float32, reflect.Float64:
-		return h.colorString(atb(v.Float()), fgCyan)
+		return h.colorString(strconv.AppendFloat(nil, v.Float(), 'g', -1, 64), h.numberColor())
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		return h.colorString(atb(v.Int()), fgCyan)
+		return h.colorString(strconv.AppendInt(nil, v.Int(), 10), h.numberColor())
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		return h.colorString(atb(v.Uint()), fgCyan)
+		return h.colorString(strconv.AppendUint(nil, v.Uint(), 10), h.numberColor())
 	case reflect.Bool:
 		c := fgRed
 		if v.Bool() {
 			c = fgGreen
 		}
 
-		return h.colorString(atb(v.Bool()), c)
+		return h.colorString(strconv.AppendBool(nil, v.Bool()), c)
 	case reflect.String:
 		s := v.String()
 		if len(s) == 0 {
 			return h.colorStringFainted([]byte("empty"), fgWhite)
 		}
-		return atb(s)
+		return h.formatLogfmtValue([]byte(h.maskNestedSecrets(h.redactString(s), vi.path)), h.stringColor(nil))
 	case reflect.Interface:
 		if v.IsZero() {
 			return h.nilString()
@@ -916,40 +2105,53 @@ func (h *developHandler) elementType(t reflect.Type, v reflect.Value, l int, p i
 
 // Inline formatters for OneLineFormat mode
 
-func (h *developHandler) formatValueInline(a slog.Attr) []byte {
-	vi := make(visited)
+func (h *Handler) formatValueInline(a slog.Attr, group []string) []byte {
+	vi := newVisited()
+	vi.path = append(group[:len(group):len(group)], a.Key)
 
 	switch a.Value.Kind() {
 	case slog.KindString:
 		val := []byte(a.Value.String())
 		if h.isJSON(string(val)) {
 			// Format as colorized JSON inline
+			if h.opts.EscapeNewlines {
+				return h.formatJSONInline(string(val))
+			}
 			jsonVal := h.formatJSONMultiline(string(val), 0)
 			return h.formatLogfmtValue(jsonVal, nil)
 		}
 		if h.isURL(val) {
-			return h.formatLogfmtValue(val, fgCyan)
+			return h.hyperlink(h.formatLogfmtValue(val, h.urlColor()), urlLink(string(val)))
 		}
-		return h.formatLogfmtValue(val, nil)
+		return h.formatLogfmtValue(h.appendQuoted(nil, string(val)), h.stringColor(nil))
 	case slog.KindFloat64, slog.KindInt64, slog.KindUint64:
-		val := []byte(a.Value.String())
-		return h.formatLogfmtValue(val, fgCyan)
+		return h.formatLogfmtValue(appendValue(nil, a.Value), h.numberColor())
 	case slog.KindBool:
 		c := fgRed
 		if a.Value.Bool() {
 			c = fgGreen
 		}
 
-		val := []byte(a.Value.String())
-		return h.formatLogfmtValue(val, c)
-	case slog.KindTime, slog.KindDuration:
+		return h.formatLogfmtValue(appendValue(nil, a.Value), c)
+	case slog.KindTime:
 		val := []byte(a.Value.String())
 		return h.formatLogfmtValue(val, fgWhite)
+	case slog.KindDuration:
+		c, _ := h.durationColor(a.Key, a.Value.Duration())
+		return h.formatLogfmtValue([]byte(a.Value.String()), c)
 	case slog.KindAny:
 		av := a.Value.Any()
 
+		// Registered type formatter
+		if tb, ok := h.formatRegisteredType(reflect.ValueOf(av)); ok {
+			return h.formatLogfmtValue(tb, nil)
+		}
+
 		// Error - use inline formatter
 		if err, ok := av.(error); ok {
+			if h.opts.QuoteValues != QuoteNever {
+				return h.formatErrorQuoted(err)
+			}
 			return h.formatLogfmtValue(h.formatError(err), nil)
 		}
 
@@ -959,11 +2161,31 @@ func (h *developHandler) formatValueInline(a slog.Attr) []byte {
 			return h.formatLogfmtValue(val, fgWhite)
 		}
 		if d, ok := av.(*time.Duration); ok {
-			val := []byte(d.String())
-			return h.formatLogfmtValue(val, fgWhite)
+			c, _ := h.durationColor(a.Key, *d)
+			return h.formatLogfmtValue([]byte(d.String()), c)
 		}
-		if d, ok := av.([]uint8); ok && utf8.Valid(d) {
-			av = string(d)
+		if e, ok := av.(Elapsed); ok {
+			return h.formatLogfmtValue([]byte(e.String()), h.elapsedColor(a.Key, e))
+		}
+		if _, ok := av.(sqlNull); ok {
+			return h.nullText()
+		}
+		if js, ok := h.jsonText(av, vi.path); ok {
+			return h.formatJSONInline(js)
+		}
+		if hv, ok := h.formatHTTP(av, vi.path); ok {
+			return hv
+		}
+		if d, ok := av.([]byte); ok {
+			return h.formatBytes(d)
+		}
+		if d, ok := av.(hexDump); ok {
+			return h.formatBytesAs(d, BytesHex)
+		}
+
+		// Text appender and formatter
+		if tb, ok := h.appendText(av); ok {
+			return h.formatLogfmtValue(tb, nil)
 		}
 
 		// Text marshaler
@@ -974,7 +2196,7 @@ func (h *developHandler) formatValueInline(a slog.Attr) []byte {
 		// Stringer
 		if h.opts.StringerFormatter {
 			if stringer, ok := av.(fmt.Stringer); ok {
-				return h.formatLogfmtValue([]byte(stringer.String()), nil)
+				return h.formatLogfmtValue(h.stringerText(stringer), nil)
 			}
 		}
 
@@ -986,7 +2208,7 @@ func (h *developHandler) formatValueInline(a slog.Attr) []byte {
 		}
 
 		ut, uv, ptrs := h.reducePointerTypeValue(avt, avv)
-		prefix := bytes.Repeat(h.colorString([]byte("*"), fgRed), ptrs)
+		prefix := h.pointerMarks(ptrs)
 
 		switch ut.Kind() {
 		case reflect.Array, reflect.Slice:
@@ -998,24 +2220,27 @@ func (h *developHandler) formatValueInline(a slog.Attr) []byte {
 		case reflect.Struct:
 			// Note: structs should be moved to multiline section by attrContainsStruct()
 			// This path is for struct elements inside inline slices/maps
+			if h.opts.EscapeNewlines {
+				return h.formatLogfmtValue(append(prefix, h.formatStructInline(avt, avv, vi)...), nil)
+			}
 			val := h.formatStruct(avt, avv, 0, vi)
 			return h.formatLogfmtValue(append(prefix, val...), nil)
 		case reflect.Float32, reflect.Float64:
-			val := atb(uv.Float())
-			return h.formatLogfmtValue(append(prefix, h.colorString(val, fgCyan)...), nil)
+			val := strconv.AppendFloat(nil, uv.Float(), 'g', -1, 64)
+			return h.formatLogfmtValue(append(prefix, h.colorString(val, h.numberColor())...), nil)
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			val := atb(uv.Int())
-			return h.formatLogfmtValue(append(prefix, h.colorString(val, fgCyan)...), nil)
+			val := strconv.AppendInt(nil, uv.Int(), 10)
+			return h.formatLogfmtValue(append(prefix, h.colorString(val, h.numberColor())...), nil)
 		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			val := atb(uv.Uint())
-			return h.formatLogfmtValue(append(prefix, h.colorString(val, fgCyan)...), nil)
+			val := strconv.AppendUint(nil, uv.Uint(), 10)
+			return h.formatLogfmtValue(append(prefix, h.colorString(val, h.numberColor())...), nil)
 		case reflect.Bool:
 			c := fgRed
 			if uv.Bool() {
 				c = fgGreen
 			}
 
-			val := atb(uv.Bool())
+			val := strconv.AppendBool(nil, uv.Bool())
 			return h.formatLogfmtValue(append(prefix, h.colorString(val, c)...), nil)
 		case reflect.String:
 			s := uv.String()
@@ -1023,10 +2248,13 @@ func (h *developHandler) formatValueInline(a slog.Attr) []byte {
 				return h.formatLogfmtValue(append(prefix, h.colorStringFainted([]byte("empty"), fgWhite)...), nil)
 			}
 			if h.isURL([]byte(s)) {
-				return h.formatLogfmtValue(append(prefix, []byte(s)...), fgCyan)
+				return h.hyperlink(h.formatLogfmtValue(append(prefix, []byte(s)...), h.urlColor()), urlLink(s))
 			}
 			if h.isJSON(s) {
 				// Format as colorized JSON inline
+				if h.opts.EscapeNewlines {
+					return h.formatJSONInline(s)
+				}
 				jsonVal := h.formatJSONMultiline(s, 0)
 				return h.formatLogfmtValue(jsonVal, nil)
 			}
@@ -1040,26 +2268,43 @@ func (h *developHandler) formatValueInline(a slog.Attr) []byte {
 	}
 }
 
-func (h *developHandler) buildTypeString(ts string) (b []byte) {
-	t := []byte(ts)
-
-	for len(t) > 0 {
-		switch t[0] {
+// buildTypeString colors pointers red, brackets green and names with Colors.Type, runs of the same color share one escape sequence
+func (h *Handler) buildTypeString(ts string) (b []byte) {
+	charColor := func(c byte) foregroundColor {
+		switch c {
 		case '*':
-			b = append(b, h.colorString([]byte{t[0]}, fgRed)...)
+			return fgRed
 		case '[', ']':
-			b = append(b, h.colorString([]byte{t[0]}, fgGreen)...)
+			return fgGreen
 		default:
-			b = append(b, h.colorString([]byte{t[0]}, fgYellow)...)
+			return h.typeColor()
+		}
+	}
+
+	for len(ts) > 0 {
+		c := charColor(ts[0])
+		n := 1
+		for n < len(ts) && bytes.Equal(charColor(ts[n]), c) {
+			n++
 		}
 
-		t = t[1:]
+		b = append(b, h.colorString([]byte(ts[:n]), c)...)
+		ts = ts[n:]
 	}
 
 	return b
 }
 
-func (h *developHandler) sortMapKeys(rv reflect.Value) []reflect.Value {
+// pointerMarks returns one red * for every dereferenced pointer
+func (h *Handler) pointerMarks(ptrs int) []byte {
+	if ptrs == 0 {
+		return nil
+	}
+
+	return h.colorString(bytes.Repeat([]byte("*"), ptrs), fgRed)
+}
+
+func (h *Handler) sortMapKeys(rv reflect.Value) []reflect.Value {
 	ks := make([]reflect.Value, 0, rv.Len())
 	ks = append(ks, rv.MapKeys()...)
 
@@ -1070,7 +2315,7 @@ func (h *developHandler) sortMapKeys(rv reflect.Value) []reflect.Value {
 	return ks
 }
 
-func (h *developHandler) reducePointerValue(v reflect.Value) reflect.Value {
+func (h *Handler) reducePointerValue(v reflect.Value) reflect.Value {
 	for v.Kind() == reflect.Pointer {
 		v = v.Elem()
 	}
@@ -1078,7 +2323,7 @@ func (h *developHandler) reducePointerValue(v reflect.Value) reflect.Value {
 	return v
 }
 
-func (h *developHandler) reducePointerTypeValue(t reflect.Type, v reflect.Value) (reflect.Type, reflect.Value, int) {
+func (h *Handler) reducePointerTypeValue(t reflect.Type, v reflect.Value) (reflect.Type, reflect.Value, int) {
 	if t == nil {
 		return t, v, 0
 	}
@@ -1098,9 +2343,84 @@ func (h *developHandler) reducePointerTypeValue(t reflect.Type, v reflect.Value)
 	return t, v, ptr
 }
 
-// Any to []byte using fmt.Sprintf
+// expandTabs replaces tabs with spaces up to the next tab stop of TabWidth
+func (h *Handler) expandTabs(s string) string {
+	if h.opts.TabWidth <= 0 || !strings.Contains(s, "\t") {
+		return s
+	}
+
+	var sb strings.Builder
+	col := 0
+	for _, r := range s {
+		switch r {
+		case '\t':
+			n := h.opts.TabWidth - col%h.opts.TabWidth
+			sb.WriteString(strings.Repeat(" ", n))
+			col += n
+		case '\n':
+			sb.WriteRune(r)
+			col = 0
+		default:
+			sb.WriteRune(r)
+			col++
+		}
+	}
+
+	return sb.String()
+}
+
+// escapeNewlines replaces newlines with \n when EscapeNewlines is enabled
+func (h *Handler) escapeNewlines(b []byte) []byte {
+	if !h.opts.EscapeNewlines || bytes.IndexAny(b, "\r\n") < 0 {
+		return b
+	}
+
+	b = bytes.ReplaceAll(b, []byte("\r"), []byte(`\r`))
+	return bytes.ReplaceAll(b, []byte("\n"), []byte(`\n`))
+}
+
+// atb formats a like fmt's %v, common types are appended without fmt
 func atb(a any) []byte {
-	return fmt.Appendf(nil, "%v", a)
+	switch v := a.(type) {
+	case string:
+		return []byte(v)
+	case int:
+		return strconv.AppendInt(nil, int64(v), 10)
+	case int64:
+		return strconv.AppendInt(nil, v, 10)
+	case int32:
+		return strconv.AppendInt(nil, int64(v), 10)
+	case uint:
+		return strconv.AppendUint(nil, uint64(v), 10)
+	case uint64:
+		return strconv.AppendUint(nil, v, 10)
+	case uint32:
+		return strconv.AppendUint(nil, uint64(v), 10)
+	case float64:
+		return strconv.AppendFloat(nil, v, 'g', -1, 64)
+	case float32:
+		return strconv.AppendFloat(nil, float64(v), 'g', -1, 32)
+	case bool:
+		return strconv.AppendBool(nil, v)
+	default:
+		return fmt.Appendf(nil, "%v", a)
+	}
+}
+
+// appendValue appends numbers and bools without converting them to a string first
+func appendValue(b []byte, v slog.Value) []byte {
+	switch v.Kind() {
+	case slog.KindInt64:
+		return strconv.AppendInt(b, v.Int64(), 10)
+	case slog.KindUint64:
+		return strconv.AppendUint(b, v.Uint64(), 10)
+	case slog.KindFloat64:
+		return strconv.AppendFloat(b, v.Float64(), 'g', -1, 64)
+	case slog.KindBool:
+		return strconv.AppendBool(b, v.Bool())
+	default:
+		return append(b, v.String()...)
+	}
 }
 
 func isNilValue(v reflect.Value) bool {
@@ -1108,12 +2428,12 @@ func isNilValue(v reflect.Value) bool {
 	return v == nilValue
 }
 
-func (h *developHandler) nilString() []byte {
+func (h *Handler) nilString() []byte {
 	return h.colorString([]byte("<nil>"), fgYellow)
 }
 
 // isJSON checks if a string value is valid JSON
-func (h *developHandler) isJSON(val string) bool {
+func (h *Handler) isJSON(val string) bool {
 	// Quick check: must start with {
 	trimmed := strings.TrimSpace(val)
 	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
@@ -1125,8 +2445,28 @@ func (h *developHandler) isJSON(val string) bool {
 	return json.Unmarshal([]byte(trimmed), &js) == nil
 }
 
+// isJSONValue checks if the value is a JSON string, skipping kinds which can't be JSON objects or arrays
+func (h *Handler) isJSONValue(v slog.Value) bool {
+	switch v.Kind() {
+	case slog.KindInt64, slog.KindUint64, slog.KindFloat64, slog.KindBool, slog.KindDuration, slog.KindTime:
+		return false
+	case slog.KindAny:
+		// Bytes print as a JSON array of numbers
+		switch v.Any().(type) {
+		case []byte, json.RawMessage, hexDump, sqlNull:
+			return false
+		}
+		if _, _, ok := typeFormatter(reflect.ValueOf(v.Any())); ok {
+			return false
+		}
+		return h.isJSON(v.String())
+	default:
+		return h.isJSON(v.String())
+	}
+}
+
 // formatJSONInline formats JSON string with colors in a compact single-line format
-func (h *developHandler) formatJSONInline(jsonStr string) []byte {
+func (h *Handler) formatJSONInline(jsonStr string) []byte {
 	trimmed := strings.TrimSpace(jsonStr)
 
 	// Compact the JSON first (remove extra whitespace)
@@ -1139,9 +2479,13 @@ func (h *developHandler) formatJSONInline(jsonStr string) []byte {
 }
 
 // formatJSONMultiline formats JSON string with colors and indentation
-func (h *developHandler) formatJSONMultiline(jsonStr string, baseIndent int) []byte {
+func (h *Handler) formatJSONMultiline(jsonStr string, baseIndent int) []byte {
 	trimmed := strings.TrimSpace(jsonStr)
 
+	if folded, ok := h.foldJSON(trimmed, baseIndent); ok {
+		return h.colorizeJSONBytes(folded, true, baseIndent)
+	}
+
 	// Pretty print the JSON
 	var indented bytes.Buffer
 	if err := json.Indent(&indented, []byte(trimmed), strings.Repeat(" ", baseIndent*2), "  "); err != nil {
@@ -1152,11 +2496,12 @@ func (h *developHandler) formatJSONMultiline(jsonStr string, baseIndent int) []b
 }
 
 // colorizeJSONBytes adds colors to JSON bytes
-func (h *developHandler) colorizeJSONBytes(data []byte, multiline bool, baseIndent int) []byte {
+func (h *Handler) colorizeJSONBytes(data []byte, multiline bool, baseIndent int) []byte {
 	var result []byte
 	inString := false
 	inKey := false
 	escape := false
+	keyColor, stringColor := h.keyColor(), h.stringColor(fgWhite)
 
 	for i := 0; i < len(data); i++ {
 		ch := data[i]
@@ -1173,6 +2518,18 @@ func (h *developHandler) colorizeJSONBytes(data []byte, multiline bool, baseInde
 			continue
 		}
 
+		// Multi-byte runes in strings are colored whole, splitting them would break the UTF-8 encoding
+		if inString && ch >= utf8.RuneSelf {
+			_, size := utf8.DecodeRune(data[i:])
+			if inKey {
+				result = append(result, h.colorString(data[i:i+size], keyColor)...)
+			} else {
+				result = append(result, h.colorString(data[i:i+size], stringColor)...)
+			}
+			i += size - 1
+			continue
+		}
+
 		switch ch {
 		case '"':
 			if !inString {
@@ -1214,26 +2571,32 @@ func (h *developHandler) colorizeJSONBytes(data []byte, multiline bool, baseInde
 				}
 				inKey = isKey
 				if inKey {
-					result = append(result, h.colorString([]byte{ch}, fgGray)...)
+					result = append(result, h.colorString([]byte{ch}, keyColor)...)
 				} else {
-					result = append(result, h.colorString([]byte{ch}, fgWhite)...)
+					result = append(result, h.colorString([]byte{ch}, stringColor)...)
 				}
 			} else {
 				// End of string
 				if inKey {
-					result = append(result, h.colorString([]byte{ch}, fgGray)...)
+					result = append(result, h.colorString([]byte{ch}, keyColor)...)
 				} else {
-					result = append(result, h.colorString([]byte{ch}, fgWhite)...)
+					result = append(result, h.colorString([]byte{ch}, stringColor)...)
 				}
 				inString = false
 				inKey = false
 			}
 		case '{', '}', '[', ']':
-			result = append(result, h.colorString([]byte{ch}, fgCyan)...)
-		case ':':
-			result = append(result, h.colorString([]byte{ch}, fgWhite)...)
-		case ',':
-			result = append(result, h.colorString([]byte{ch}, fgWhite)...)
+			result = append(result, h.colorString([]byte{ch}, h.punctColor(fgCyan))...)
+		case jsonFoldMarker:
+			// Placeholder of a folded value
+			end := bytes.IndexByte(data[i+1:], jsonFoldMarker)
+			if end < 0 {
+				end = len(data) - i - 1
+			}
+			result = append(result, h.faintedText(data[i+1:i+1+end])...)
+			i += end + 1
+		case ':', ',':
+			result = append(result, h.colorString([]byte{ch}, h.punctColor(stringColor))...)
 		case 't', 'f': // true/false
 			if !inString {
 				// Check if this is the start of true or false
@@ -1248,9 +2611,9 @@ func (h *developHandler) colorizeJSONBytes(data []byte, multiline bool, baseInde
 				}
 			} else {
 				if inKey {
-					result = append(result, h.colorString([]byte{ch}, fgGray)...)
+					result = append(result, h.colorString([]byte{ch}, keyColor)...)
 				} else {
-					result = append(result, h.colorString([]byte{ch}, fgWhite)...)
+					result = append(result, h.colorString([]byte{ch}, stringColor)...)
 				}
 			}
 		case 'n': // null
@@ -1259,9 +2622,9 @@ func (h *developHandler) colorizeJSONBytes(data []byte, multiline bool, baseInde
 				i += 3
 			} else if inString {
 				if inKey {
-					result = append(result, h.colorString([]byte{ch}, fgGray)...)
+					result = append(result, h.colorString([]byte{ch}, keyColor)...)
 				} else {
-					result = append(result, h.colorString([]byte{ch}, fgWhite)...)
+					result = append(result, h.colorString([]byte{ch}, stringColor)...)
 				}
 			} else {
 				result = append(result, ch)
@@ -1274,20 +2637,20 @@ func (h *developHandler) colorizeJSONBytes(data []byte, multiline bool, baseInde
 					i++
 				}
 				i-- // Back up one since the loop will increment
-				result = append(result, h.colorString(data[numStart:i+1], fgCyan)...)
+				result = append(result, h.colorString(data[numStart:i+1], h.numberColor())...)
 			} else {
 				if inKey {
-					result = append(result, h.colorString([]byte{ch}, fgGray)...)
+					result = append(result, h.colorString([]byte{ch}, keyColor)...)
 				} else {
-					result = append(result, h.colorString([]byte{ch}, fgWhite)...)
+					result = append(result, h.colorString([]byte{ch}, stringColor)...)
 				}
 			}
 		default:
 			if inString {
 				if inKey {
-					result = append(result, h.colorString([]byte{ch}, fgGray)...)
+					result = append(result, h.colorString([]byte{ch}, keyColor)...)
 				} else {
-					result = append(result, h.colorString([]byte{ch}, fgWhite)...)
+					result = append(result, h.colorString([]byte{ch}, stringColor)...)
 				}
 			} else {
 				result = append(result, ch)
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"regexp"
@@ -27,6 +28,13 @@ func TestMethods(t *testing.T) {
 	testWithGroupEmpty(t)
 	testWithAttrs(t)
 	testWithAttrsEmpty(t)
+	testClone(t)
+	testWithOptions(t)
+	testWithOptionsState(t)
+	testWithOptionsColorAndSampling(t)
+	testHandlerOptions(t)
+	testSetOutput(t)
+	testSetOutputColors(t)
 }
 
 func TestLevels(t *testing.T) {
@@ -41,11 +49,15 @@ func TestGroupsAndAttributes(t *testing.T) {
 	testWithGroupsEmpty(t)
 	testWithAttributes(t)
 	testWithAttributesRaceCondition()
+	testInlineGroups(t)
+	testInlineGroupsOverThreshold(t)
 }
 
 func TestSourceAndReplace(t *testing.T) {
 	testSource(t)
 	testReplaceLevelAttributes(t)
+	testReplaceLevelNotMutatingRecord(t)
+	testHideRenamedLevel(t)
 }
 
 func TestTypes(t *testing.T) {
@@ -104,7 +116,7 @@ func testNewHandlerDefaults(t *testing.T) {
 		t.Errorf("Expected default TimeFormat to be \"[15:04:05]\" ")
 	}
 
-	if h.out == nil {
+	if h.out.w == nil {
 		t.Errorf("Expected writer to be initialized")
 	}
 }
@@ -141,7 +153,7 @@ func testNewHandlerWithNilOptions(t *testing.T) {
 		t.Errorf("Expected MaxSlicePrintSize to be initialized with default value")
 	}
 
-	if h.out != nil {
+	if h.out.w != nil {
 		t.Errorf("Expected writer to be nil")
 	}
 }
@@ -158,7 +170,7 @@ func testNewHandlerWithNilSlogHandlerOptions(t *testing.T) {
 		t.Errorf("Expected MaxSlicePrintSize to be initialized with default value")
 	}
 
-	if h.out != nil {
+	if h.out.w != nil {
 		t.Errorf("Expected writer to be nil")
 	}
 }
@@ -212,6 +224,157 @@ func testWithAttrsEmpty(t *testing.T) {
 	}
 }
 
+func testClone(t *testing.T) {
+	h := NewHandler(nil, nil)
+	h2 := h.Clone()
+
+	if h2 == h {
+		t.Error("Expected a new handler instance")
+	}
+
+	if h2.mu != h.mu {
+		t.Error("Expected clone to share the lock")
+	}
+
+	if h2.opts.HandlerOptions == h.opts.HandlerOptions {
+		t.Error("Expected clone to have its own slog.HandlerOptions")
+	}
+}
+
+func testWithOptions(t *testing.T) {
+	w := &MockWriter{}
+	opts := &Options{
+		HandlerOptions:    &slog.HandlerOptions{Level: slog.LevelInfo},
+		MaxSlicePrintSize: 4,
+		TimeFormat:        "[]",
+	}
+
+	h := NewHandler(w, opts)
+	h2 := h.WithOptions(func(o *Options) {
+		o.NoColor = true
+		o.MaxSlicePrintSize = 0
+		o.Level = slog.LevelDebug
+	})
+
+	if h.opts.NoColor || h.opts.Level.Level() != slog.LevelInfo {
+		t.Error("Expected original handler options to be unchanged")
+	}
+
+	if h2.opts.MaxSlicePrintSize != 50 {
+		t.Errorf("Expected zero MaxSlicePrintSize to fall back to default, got %d", h2.opts.MaxSlicePrintSize)
+	}
+
+	slog.New(h2.WithAttrs([]slog.Attr{slog.Int("a", 1)})).Debug("msg")
+
+	expected := "[]  DEBUG  msg a=1\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testWithOptionsState(t *testing.T) {
+	w := &MockWriter{}
+	h := NewHandler(w, &Options{TimeFormat: "[]", NoColor: true})
+	h2 := h.WithOptions(func(o *Options) {
+		o.TableMode = true
+		o.RecentRecords = 2
+	})
+
+	if h2.table == nil || h2.recent == nil {
+		t.Fatal("Expected WithOptions to create the state of enabled options")
+	}
+
+	if h.table != nil || h.recent != nil {
+		t.Error("Expected original handler state to be unchanged")
+	}
+
+	h3 := h2.WithOptions(func(o *Options) {
+		o.TableMode = false
+	})
+
+	if h3.table != nil || h3.recent != h2.recent {
+		t.Error("Expected WithOptions to drop the state of disabled options and share the rest")
+	}
+}
+
+func testWithOptionsColorAndSampling(t *testing.T) {
+	h := NewHandler(&MockWriter{}, &Options{NoColor: true, Sampling: map[slog.Level]SamplingRate{slog.LevelInfo: {Burst: 1}}})
+	h2 := h.WithOptions(func(o *Options) {
+		o.NoColor = false
+		o.Sampling = map[slog.Level]SamplingRate{slog.LevelInfo: {Burst: 2}}
+	})
+
+	if h.progress != nil || h2.progress == nil {
+		t.Error("Expected the progress display to be created when NoColor is turned off")
+	}
+
+	if h2.sampler == nil || h2.sampler == h.sampler {
+		t.Error("Expected changed Sampling to get its own sampler")
+	}
+
+	if h3 := h2.WithOptions(func(o *Options) { o.MaxAttrs = 1 }); h3.sampler != h2.sampler || h3.progress != h2.progress {
+		t.Error("Expected the state of unchanged options to be shared")
+	}
+}
+
+func testHandlerOptions(t *testing.T) {
+	h := NewHandler(&MockWriter{}, &Options{MaxAttrs: 3})
+
+	o := h.Options()
+	if o.MaxAttrs != 3 || o.TimeFormat != "[15:04:05]" {
+		t.Errorf("Expected options with defaults, got MaxAttrs %d and TimeFormat %q", o.MaxAttrs, o.TimeFormat)
+	}
+
+	o.Level = slog.LevelError
+	if h.opts.Level.Level() != slog.LevelInfo {
+		t.Error("Expected changes of the returned options not to affect the handler")
+	}
+}
+
+func testSetOutput(t *testing.T) {
+	w1 := &MockWriter{}
+	w2 := &MockWriter{}
+	h := NewHandler(w1, &Options{TimeFormat: "[]", NoColor: true, BufferSize: 64})
+	logger := slog.New(h).With("a", 1)
+
+	logger.Info("first")
+	if err := h.SetOutput(w2); err != nil {
+		t.Fatal(err)
+	}
+	logger.Info("second")
+	if err := h.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	if string(w1.WrittenData) != "[]  INFO  first a=1\n" || string(w2.WrittenData) != "[]  INFO  second a=1\n" {
+		t.Errorf("Expected records split between writers, got %q and %q", w1.WrittenData, w2.WrittenData)
+	}
+}
+
+func testSetOutputColors(t *testing.T) {
+	w := &MockWriter{}
+	h := NewHandler(NewColorWriter(&MockWriter{}, true), &Options{TimeFormat: "[]", IgnoreEnv: true})
+	if h.opts.NoColor || h.progress == nil {
+		t.Fatal("Expected colors for a colored ColorWriter")
+	}
+
+	if err := h.SetOutput(NewColorWriter(w, false)); err != nil {
+		t.Fatal(err)
+	}
+	slog.New(h).Info("msg")
+
+	if !h.opts.NoColor || h.progress != nil {
+		t.Error("Expected colors to be detected again for the new writer")
+	}
+
+	expected := "[]  INFO  msg\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
 func testLevelMessageDebug(t *testing.T) {
 	h := NewHandler(nil, nil)
 	buf := make([]byte, 0)
@@ -435,6 +598,51 @@ func testWithAttributesRaceCondition() {
 	}()
 }
 
+func testInlineGroups(t *testing.T) {
+	w := &MockWriter{}
+
+	opts := &Options{
+		TimeFormat:          "[]",
+		InlineGroupMaxAttrs: 2,
+	}
+
+	logger := slog.New(NewHandler(w, opts))
+
+	logger.Info("msg",
+		slog.Group("g", slog.Int("a", 1), slog.Group("n", slog.String("b", "x"))),
+	)
+
+	expected := "\x1b[2m[]\x1b[0m \x1b[42m\x1b[30m INFO \x1b[0m msg \x1b[90mg=\x1b[0m{\x1b[90ma=\x1b[0m\x1b[36m1\x1b[0m \x1b[90mn=\x1b[0m{\x1b[90mb=\x1b[0mx}}\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testInlineGroupsOverThreshold(t *testing.T) {
+	w := &MockWriter{}
+
+	opts := &Options{
+		TimeFormat:          "[]",
+		NoColor:             true,
+		InlineGroupMaxAttrs: 5,
+		InlineGroupMaxWidth: 12,
+	}
+
+	logger := slog.New(NewHandler(w, opts))
+
+	logger.Info("msg",
+		slog.Group("small", slog.Int("a", 1)),
+		slog.Group("wide", slog.String("a", "long value"), slog.Int("b", 2)),
+	)
+
+	expected := "[]  INFO  msg small={a=1}G wide=\n   a=long value\n  # b=2\n\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
 const (
 	LevelTrace     = slog.Level(-8)
 	LevelDebug     = slog.LevelDebug
@@ -481,6 +689,60 @@ func testReplaceLevelAttributes(t *testing.T) {
 	}
 }
 
+func testReplaceLevelNotMutatingRecord(t *testing.T) {
+	w := &MockWriter{}
+
+	opts := &Options{
+		HandlerOptions: &slog.HandlerOptions{ReplaceAttr: replaceAttributes},
+		TimeFormat:     "[]",
+		NoColor:        true,
+	}
+
+	h := NewHandler(w, opts)
+
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0)
+	for i := 0; i < 6; i++ {
+		r.AddAttrs(slog.Int(fmt.Sprint(i), i))
+	}
+
+	// Handling the same record twice used to panic with "copies of a slog.Record were both modified"
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if err := h.Handle(ctx, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if r.NumAttrs() != 6 {
+		t.Errorf("Expected record to keep 6 attributes, got %d", r.NumAttrs())
+	}
+
+	expected := "[]  INFO  msg 0=0 1=1 2=2 3=3 4=4 5=5 sev=INFO\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected+expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected+expected, w.WrittenData)
+	}
+}
+
+func testHideRenamedLevel(t *testing.T) {
+	w := &MockWriter{}
+
+	opts := &Options{
+		HandlerOptions:   &slog.HandlerOptions{ReplaceAttr: replaceAttributes},
+		TimeFormat:       "[]",
+		NoColor:          true,
+		HideRenamedLevel: true,
+	}
+
+	slog.New(NewHandler(w, opts)).Warn("msg")
+
+	expected := "[]  WARNING  msg\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
 func replaceAttributes(groups []string, a slog.Attr) slog.Attr {
 	if a.Key == slog.LevelKey {
 		// Rename the level key from "level" to "sev".
@@ -634,7 +896,7 @@ func testSlice(t *testing.T, o *Options) {
 	)
 
 	expected := []byte(
-		"\x1b[2m[]\x1b[0m \x1b[42m\x1b[30m INFO \x1b[0m msg \x1b[90ms=\x1b[0m\x1b[36m2\x1b[0m \x1b[32m[\x1b[0m\x1b[32m]\x1b[0m\x1b[33ms\x1b[0m\x1b[33mt\x1b[0m\x1b[33mr\x1b[0m\x1b[33mi\x1b[0m\x1b[33mn\x1b[0m\x1b[33mg\x1b[0m\x1b[32m{\x1b[0mapple ba na na\x1b[32m}\x1b[0m\n\n",
+		"\x1b[2m[]\x1b[0m \x1b[42m\x1b[30m INFO \x1b[0m msg \x1b[90ms=\x1b[0m\x1b[36m2\x1b[0m \x1b[32m[]\x1b[0m\x1b[33mstring\x1b[0m\x1b[32m{\x1b[0mapple ba na na\x1b[32m}\x1b[0m\n\n",
 	)
 
 	if !bytes.Equal(w.WrittenData, expected) {
@@ -656,7 +918,7 @@ func testSliceBig(t *testing.T, o *Options) {
 	)
 
 	expected := []byte(
-		"\x1b[2m[]\x1b[0m \x1b[42m\x1b[30m INFO \x1b[0m msg \x1b[90ms=\x1b[0m\x1b[36m11\x1b[0m \x1b[32m[\x1b[0m\x1b[32m]\x1b[0m\x1b[33mi\x1b[0m\x1b[33mn\x1b[0m\x1b[33mt\x1b[0m\x1b[32m{\x1b[0m\x1b[36m0\x1b[0m \x1b[36m2\x1b[0m \x1b[36m4\x1b[0m \x1b[36m6\x1b[0m \x1b[36m...\x1b[0m\x1b[32m}\x1b[0m\n\n",
+		"\x1b[2m[]\x1b[0m \x1b[42m\x1b[30m INFO \x1b[0m msg \x1b[90ms=\x1b[0m\x1b[36m11\x1b[0m \x1b[32m[]\x1b[0m\x1b[33mint\x1b[0m\x1b[32m{\x1b[0m\x1b[36m0\x1b[0m \x1b[36m2\x1b[0m \x1b[36m4\x1b[0m \x1b[36m6\x1b[0m \x1b[36m...\x1b[0m\x1b[32m}\x1b[0m\n\n",
 	)
 
 	if !bytes.Equal(w.WrittenData, expected) {
@@ -678,7 +940,7 @@ func testMap(t *testing.T, o *Options) {
 	)
 
 	expected := []byte(
-		"\x1b[2m[]\x1b[0m \x1b[42m\x1b[30m INFO \x1b[0m msg \x1b[90mm=\x1b[0m\x1b[36m2\x1b[0m \x1b[33mm\x1b[0m\x1b[33ma\x1b[0m\x1b[33mp\x1b[0m\x1b[32m[\x1b[0m\x1b[33mi\x1b[0m\x1b[33mn\x1b[0m\x1b[33mt\x1b[0m\x1b[32m]\x1b[0m\x1b[33ms\x1b[0m\x1b[33mt\x1b[0m\x1b[33mr\x1b[0m\x1b[33mi\x1b[0m\x1b[33mn\x1b[0m\x1b[33mg\x1b[0m\x1b[32m{\x1b[0m\x1b[32m0\x1b[0m=a \x1b[32m1\x1b[0m=b\x1b[32m}\x1b[0m \x1b[90mmp=\x1b[0m\x1b[31m*\x1b[0m\x1b[36m2\x1b[0m \x1b[31m*\x1b[0m\x1b[33mm\x1b[0m\x1b[33ma\x1b[0m\x1b[33mp\x1b[0m\x1b[32m[\x1b[0m\x1b[33mi\x1b[0m\x1b[33mn\x1b[0m\x1b[33mt\x1b[0m\x1b[32m]\x1b[0m\x1b[33ms\x1b[0m\x1b[33mt\x1b[0m\x1b[33mr\x1b[0m\x1b[33mi\x1b[0m\x1b[33mn\x1b[0m\x1b[33mg\x1b[0m\x1b[32m{\x1b[0m\x1b[32m0\x1b[0m=a \x1b[32m1\x1b[0m=b\x1b[32m}\x1b[0m \x1b[90mmpp=\x1b[0m\x1b[31m*\x1b[0m\x1b[31m*\x1b[0m\x1b[36m2\x1b[0m \x1b[31m*\x1b[0m\x1b[31m*\x1b[0m\x1b[33mm\x1b[0m\x1b[33ma\x1b[0m\x1b[33mp\x1b[0m\x1b[32m[\x1b[0m\x1b[33mi\x1b[0m\x1b[33mn\x1b[0m\x1b[33mt\x1b[0m\x1b[32m]\x1b[0m\x1b[33ms\x1b[0m\x1b[33mt\x1b[0m\x1b[33mr\x1b[0m\x1b[33mi\x1b[0m\x1b[33mn\x1b[0m\x1b[33mg\x1b[0m\x1b[32m{\x1b[0m\x1b[32m0\x1b[0m=a \x1b[32m1\x1b[0m=b\x1b[32m}\x1b[0m\n\n",
+		"\x1b[2m[]\x1b[0m \x1b[42m\x1b[30m INFO \x1b[0m msg \x1b[90mm=\x1b[0m\x1b[36m2\x1b[0m \x1b[33mmap\x1b[0m\x1b[32m[\x1b[0m\x1b[33mint\x1b[0m\x1b[32m]\x1b[0m\x1b[33mstring\x1b[0m\x1b[32m{\x1b[0m\x1b[32m0\x1b[0m=a \x1b[32m1\x1b[0m=b\x1b[32m}\x1b[0m \x1b[90mmp=\x1b[0m\x1b[31m*\x1b[0m\x1b[36m2\x1b[0m \x1b[31m*\x1b[0m\x1b[33mmap\x1b[0m\x1b[32m[\x1b[0m\x1b[33mint\x1b[0m\x1b[32m]\x1b[0m\x1b[33mstring\x1b[0m\x1b[32m{\x1b[0m\x1b[32m0\x1b[0m=a \x1b[32m1\x1b[0m=b\x1b[32m}\x1b[0m \x1b[90mmpp=\x1b[0m\x1b[31m**\x1b[0m\x1b[36m2\x1b[0m \x1b[31m**\x1b[0m\x1b[33mmap\x1b[0m\x1b[32m[\x1b[0m\x1b[33mint\x1b[0m\x1b[32m]\x1b[0m\x1b[33mstring\x1b[0m\x1b[32m{\x1b[0m\x1b[32m0\x1b[0m=a \x1b[32m1\x1b[0m=b\x1b[32m}\x1b[0m\n\n",
 	)
 
 	if !bytes.Equal(w.WrittenData, expected) {
@@ -698,7 +960,7 @@ func testMapOfPointers(t *testing.T, o *Options) {
 	)
 
 	expected := []byte(
-		"\x1b[2m[]\x1b[0m \x1b[42m\x1b[30m INFO \x1b[0m msg \x1b[90mm=\x1b[0m\x1b[36m2\x1b[0m \x1b[33mm\x1b[0m\x1b[33ma\x1b[0m\x1b[33mp\x1b[0m\x1b[32m[\x1b[0m\x1b[33mi\x1b[0m\x1b[33mn\x1b[0m\x1b[33mt\x1b[0m\x1b[32m]\x1b[0m\x1b[31m*\x1b[0m\x1b[33ms\x1b[0m\x1b[33mt\x1b[0m\x1b[33mr\x1b[0m\x1b[33mi\x1b[0m\x1b[33mn\x1b[0m\x1b[33mg\x1b[0m\x1b[32m{\x1b[0m\x1b[32m0\x1b[0m=a \x1b[32m1\x1b[0m=a\x1b[32m}\x1b[0m\n\n",
+		"\x1b[2m[]\x1b[0m \x1b[42m\x1b[30m INFO \x1b[0m msg \x1b[90mm=\x1b[0m\x1b[36m2\x1b[0m \x1b[33mmap\x1b[0m\x1b[32m[\x1b[0m\x1b[33mint\x1b[0m\x1b[32m]\x1b[0m\x1b[31m*\x1b[0m\x1b[33mstring\x1b[0m\x1b[32m{\x1b[0m\x1b[32m0\x1b[0m=a \x1b[32m1\x1b[0m=a\x1b[32m}\x1b[0m\n\n",
 	)
 
 	if !bytes.Equal(w.WrittenData, expected) {
@@ -720,7 +982,7 @@ func testMapOfInterface(t *testing.T, o *Options) {
 	)
 
 	expected := []byte(
-		"\x1b[2m[]\x1b[0m \x1b[42m\x1b[30m INFO \x1b[0m msg \x1b[90mm=\x1b[0m\x1b[36m2\x1b[0m \x1b[33mm\x1b[0m\x1b[33ma\x1b[0m\x1b[33mp\x1b[0m\x1b[32m[\x1b[0m\x1b[33mi\x1b[0m\x1b[33mn\x1b[0m\x1b[33mt\x1b[0m\x1b[32m]\x1b[0m\x1b[33mi\x1b[0m\x1b[33mn\x1b[0m\x1b[33mt\x1b[0m\x1b[33me\x1b[0m\x1b[33mr\x1b[0m\x1b[33mf\x1b[0m\x1b[33ma\x1b[0m\x1b[33mc\x1b[0m\x1b[33me\x1b[0m\x1b[33m \x1b[0m\x1b[33m{\x1b[0m\x1b[33m}\x1b[0m\x1b[32m{\x1b[0m\x1b[32m0\x1b[0m=a \x1b[32m1\x1b[0m=b\x1b[32m}\x1b[0m \x1b[90mmp=\x1b[0m\x1b[31m*\x1b[0m\x1b[36m2\x1b[0m \x1b[31m*\x1b[0m\x1b[33mm\x1b[0m\x1b[33ma\x1b[0m\x1b[33mp\x1b[0m\x1b[32m[\x1b[0m\x1b[33mi\x1b[0m\x1b[33mn\x1b[0m\x1b[33mt\x1b[0m\x1b[32m]\x1b[0m\x1b[33mi\x1b[0m\x1b[33mn\x1b[0m\x1b[33mt\x1b[0m\x1b[33me\x1b[0m\x1b[33mr\x1b[0m\x1b[33mf\x1b[0m\x1b[33ma\x1b[0m\x1b[33mc\x1b[0m\x1b[33me\x1b[0m\x1b[33m \x1b[0m\x1b[33m{\x1b[0m\x1b[33m}\x1b[0m\x1b[32m{\x1b[0m\x1b[32m0\x1b[0m=a \x1b[32m1\x1b[0m=b\x1b[32m}\x1b[0m \x1b[90mmpp=\x1b[0m\x1b[31m*\x1b[0m\x1b[31m*\x1b[0m\x1b[36m2\x1b[0m \x1b[31m*\x1b[0m\x1b[31m*\x1b[0m\x1b[33mm\x1b[0m\x1b[33ma\x1b[0m\x1b[33mp\x1b[0m\x1b[32m[\x1b[0m\x1b[33mi\x1b[0m\x1b[33mn\x1b[0m\x1b[33mt\x1b[0m\x1b[32m]\x1b[0m\x1b[33mi\x1b[0m\x1b[33mn\x1b[0m\x1b[33mt\x1b[0m\x1b[33me\x1b[0m\x1b[33mr\x1b[0m\x1b[33mf\x1b[0m\x1b[33ma\x1b[0m\x1b[33mc\x1b[0m\x1b[33me\x1b[0m\x1b[33m \x1b[0m\x1b[33m{\x1b[0m\x1b[33m}\x1b[0m\x1b[32m{\x1b[0m\x1b[32m0\x1b[0m=a \x1b[32m1\x1b[0m=b\x1b[32m}\x1b[0m\n\n",
+		"\x1b[2m[]\x1b[0m \x1b[42m\x1b[30m INFO \x1b[0m msg \x1b[90mm=\x1b[0m\x1b[36m2\x1b[0m \x1b[33mmap\x1b[0m\x1b[32m[\x1b[0m\x1b[33mint\x1b[0m\x1b[32m]\x1b[0m\x1b[33minterface {}\x1b[0m\x1b[32m{\x1b[0m\x1b[32m0\x1b[0m=a \x1b[32m1\x1b[0m=b\x1b[32m}\x1b[0m \x1b[90mmp=\x1b[0m\x1b[31m*\x1b[0m\x1b[36m2\x1b[0m \x1b[31m*\x1b[0m\x1b[33mmap\x1b[0m\x1b[32m[\x1b[0m\x1b[33mint\x1b[0m\x1b[32m]\x1b[0m\x1b[33minterface {}\x1b[0m\x1b[32m{\x1b[0m\x1b[32m0\x1b[0m=a \x1b[32m1\x1b[0m=b\x1b[32m}\x1b[0m \x1b[90mmpp=\x1b[0m\x1b[31m**\x1b[0m\x1b[36m2\x1b[0m \x1b[31m**\x1b[0m\x1b[33mmap\x1b[0m\x1b[32m[\x1b[0m\x1b[33mint\x1b[0m\x1b[32m]\x1b[0m\x1b[33minterface {}\x1b[0m\x1b[32m{\x1b[0m\x1b[32m0\x1b[0m=a \x1b[32m1\x1b[0m=b\x1b[32m}\x1b[0m\n\n",
 	)
 
 	if !bytes.Equal(w.WrittenData, expected) {
@@ -757,7 +1019,7 @@ func testStruct(t *testing.T, o *Options) {
 	)
 
 	expected := []byte(
-		"\x1b[2m[]\x1b[0m \x1b[42m\x1b[30m INFO \x1b[0m msg\x1b[33mS\x1b[0m \x1b[90ms\x1b[0m=\x1b[31m*\x1b[0m\x1b[33mh\x1b[0m\x1b[33mu\x1b[0m\x1b[33mm\x1b[0m\x1b[33ma\x1b[0m\x1b[33mn\x1b[0m\x1b[33ms\x1b[0m\x1b[33ml\x1b[0m\x1b[33mo\x1b[0m\x1b[33mg\x1b[0m\x1b[33m.\x1b[0m\x1b[33mS\x1b[0m\x1b[33mt\x1b[0m\x1b[33mr\x1b[0m\x1b[33mu\x1b[0m\x1b[33mc\x1b[0m\x1b[33mt\x1b[0m\x1b[33mT\x1b[0m\x1b[33me\x1b[0m\x1b[33ms\x1b[0m\x1b[33mt\x1b[0m\n    \x1b[32mSlice\x1b[0m  : \x1b[36m0\x1b[0m \x1b[32m[\x1b[0m\x1b[32m]\x1b[0m\x1b[33mi\x1b[0m\x1b[33mn\x1b[0m\x1b[33mt\x1b[0m\x1b[32m{\x1b[0m\x1b[32m}\x1b[0m\n    \x1b[32mMap\x1b[0m    : \x1b[36m0\x1b[0m \x1b[33mm\x1b[0m\x1b[33ma\x1b[0m\x1b[33mp\x1b[0m\x1b[32m[\x1b[0m\x1b[33mi\x1b[0m\x1b[33mn\x1b[0m\x1b[33mt\x1b[0m\x1b[32m]\x1b[0m\x1b[33mi\x1b[0m\x1b[33mn\x1b[0m\x1b[33mt\x1b[0m\x1b[32m{\x1b[0m\x1b[32m}\x1b[0m\n    \x1b[32mStruct\x1b[0m : \x1b[33ms\x1b[0m\x1b[33mt\x1b[0m\x1b[33mr\x1b[0m\x1b[33mu\x1b[0m\x1b[33mc\x1b[0m\x1b[33mt\x1b[0m\x1b[33m \x1b[0m\x1b[33m{\x1b[0m\x1b[33m \x1b[0m\x1b[33mB\x1b[0m\x1b[33m \x1b[0m\x1b[33mb\x1b[0m\x1b[33mo\x1b[0m\x1b[33mo\x1b[0m\x1b[33ml\x1b[0m\x1b[33m \x1b[0m\x1b[33m}\x1b[0m\n      \x1b[32mB\x1b[0m: \x1b[31mfalse\x1b[0m\n    \x1b[32mSliceP\x1b[0m : \x1b[36m0\x1b[0m \x1b[31m*\x1b[0m\x1b[32m[\x1b[0m\x1b[32m]\x1b[0m\x1b[33mi\x1b[0m\x1b[33mn\x1b[0m\x1b[33mt\x1b[0m\x1b[32m{\x1b[0m\x1b[32m}\x1b[0m\n    \x1b[32mMapP\x1b[0m   : \x1b[36m0\x1b[0m \x1b[31m*\x1b[0m\x1b[33mm\x1b[0m\x1b[33ma\x1b[0m\x1b[33mp\x1b[0m\x1b[32m[\x1b[0m\x1b[33mi\x1b[0m\x1b[33mn\x1b[0m\x1b[33mt\x1b[0m\x1b[32m]\x1b[0m\x1b[33mi\x1b[0m\x1b[33mn\x1b[0m\x1b[33mt\x1b[0m\x1b[32m{\x1b[0m\x1b[32m}\x1b[0m\n    \x1b[32mStructP\x1b[0m: \x1b[31m*\x1b[0m\x1b[33ms\x1b[0m\x1b[33mt\x1b[0m\x1b[33mr\x1b[0m\x1b[33mu\x1b[0m\x1b[33mc\x1b[0m\x1b[33mt\x1b[0m\x1b[33m \x1b[0m\x1b[33m{\x1b[0m\x1b[33m \x1b[0m\x1b[33mB\x1b[0m\x1b[33m \x1b[0m\x1b[33mb\x1b[0m\x1b[33mo\x1b[0m\x1b[33mo\x1b[0m\x1b[33ml\x1b[0m\x1b[33m \x1b[0m\x1b[33m}\x1b[0m\n      \x1b[32mB\x1b[0m: \x1b[31mfalse\x1b[0m\n\n\n",
+		"\x1b[2m[]\x1b[0m \x1b[42m\x1b[30m INFO \x1b[0m msg\x1b[33mS\x1b[0m \x1b[90ms\x1b[0m=\x1b[31m*\x1b[0m\x1b[33mhumanslog.StructTest\x1b[0m\n    \x1b[32mSlice\x1b[0m  : \x1b[36m0\x1b[0m \x1b[32m[]\x1b[0m\x1b[33mint\x1b[0m\x1b[32m{\x1b[0m\x1b[32m}\x1b[0m\n    \x1b[32mMap\x1b[0m    : \x1b[36m0\x1b[0m \x1b[33mmap\x1b[0m\x1b[32m[\x1b[0m\x1b[33mint\x1b[0m\x1b[32m]\x1b[0m\x1b[33mint\x1b[0m\x1b[32m{\x1b[0m\x1b[32m}\x1b[0m\n    \x1b[32mStruct\x1b[0m : \x1b[33mstruct { B bool }\x1b[0m\n      \x1b[32mB\x1b[0m: \x1b[31mfalse\x1b[0m\n    \x1b[32mSliceP\x1b[0m : \x1b[36m0\x1b[0m \x1b[31m*\x1b[0m\x1b[32m[]\x1b[0m\x1b[33mint\x1b[0m\x1b[32m{\x1b[0m\x1b[32m}\x1b[0m\n    \x1b[32mMapP\x1b[0m   : \x1b[36m0\x1b[0m \x1b[31m*\x1b[0m\x1b[33mmap\x1b[0m\x1b[32m[\x1b[0m\x1b[33mint\x1b[0m\x1b[32m]\x1b[0m\x1b[33mint\x1b[0m\x1b[32m{\x1b[0m\x1b[32m}\x1b[0m\n    \x1b[32mStructP\x1b[0m: \x1b[31m*\x1b[0m\x1b[33mstruct { B bool }\x1b[0m\n      \x1b[32mB\x1b[0m: \x1b[31mfalse\x1b[0m\n\n\n",
 	)
 
 	if !bytes.Equal(w.WrittenData, expected) {
@@ -780,7 +1042,7 @@ func testNilInterface(t *testing.T, o *Options) {
 	)
 
 	expected := []byte(
-		"\x1b[2m[]\x1b[0m \x1b[42m\x1b[30m INFO \x1b[0m msg\x1b[33mS\x1b[0m \x1b[90ms\x1b[0m=\x1b[33mh\x1b[0m\x1b[33mu\x1b[0m\x1b[33mm\x1b[0m\x1b[33ma\x1b[0m\x1b[33mn\x1b[0m\x1b[33ms\x1b[0m\x1b[33ml\x1b[0m\x1b[33mo\x1b[0m\x1b[33mg\x1b[0m\x1b[33m.\x1b[0m\x1b[33mS\x1b[0m\x1b[33mt\x1b[0m\x1b[33mr\x1b[0m\x1b[33mu\x1b[0m\x1b[33mc\x1b[0m\x1b[33mt\x1b[0m\x1b[33mW\x1b[0m\x1b[33mi\x1b[0m\x1b[33mt\x1b[0m\x1b[33mh\x1b[0m\x1b[33mI\x1b[0m\x1b[33mn\x1b[0m\x1b[33mt\x1b[0m\x1b[33me\x1b[0m\x1b[33mr\x1b[0m\x1b[33mf\x1b[0m\x1b[33ma\x1b[0m\x1b[33mc\x1b[0m\x1b[33me\x1b[0m\n    \x1b[32mData\x1b[0m: \x1b[33m<nil>\x1b[0m\n\n\n",
+		"\x1b[2m[]\x1b[0m \x1b[42m\x1b[30m INFO \x1b[0m msg\x1b[33mS\x1b[0m \x1b[90ms\x1b[0m=\x1b[33mhumanslog.StructWithInterface\x1b[0m\n    \x1b[32mData\x1b[0m: \x1b[33m<nil>\x1b[0m\n\n\n",
 	)
 
 	if !bytes.Equal(w.WrittenData, expected) {
@@ -1014,6 +1276,15 @@ func TestOneLineFormat(t *testing.T) {
 	testOneLineWithMapInline(t)
 	testOneLineWithMultilineFallbackUsesEquals(t)
 	testOneLineNoPadding(t)
+	testOneLineWrapWidth(t)
+	testOneLineEscapeNewlines(t)
+	testOneLineTabWidth(t)
+	testOneLineMaxAttrs(t)
+	testOneLineMaxAttrsDebug(t)
+	testOneLineSlicePrintSizeSentinels(t)
+	testOneLineMapPrintSize(t)
+	testOneLineWrapWidthPacked(t)
+	testOneLineWrapTerminalWidth(t)
 }
 
 func testOneLineBasic(t *testing.T) {
@@ -1630,8 +1901,233 @@ func testOneLineNoPadding(t *testing.T) {
 	}
 }
 
+func testOneLineWrapWidth(t *testing.T) {
+	w := &MockWriter{}
+
+	opts := &Options{
+		TimeFormat: "[]",
+		NoColor:    true,
+		WrapWidth:  30,
+	}
+
+	logger := slog.New(NewHandler(w, opts))
+
+	logger.Info("test message",
+		slog.Int("a", 1),
+		slog.String("b", "long value"),
+		slog.Int("c", 3),
+	)
+
+	expected := "[]  INFO  test message a=1\n          b=long value c=3\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testOneLineWrapTerminalWidth(t *testing.T) {
+	for _, wrapWidth := range []int{0, NoWrap} {
+		w := &MockWriter{}
+		h := NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, WrapWidth: wrapWidth})
+		// a terminal 30 columns wide
+		h.out.width.Store(30)
+
+		slog.New(h).Info("test message", slog.Int("a", 1), slog.String("b", "long value"), slog.Int("c", 3))
+
+		expected := "[]  INFO  test message a=1\n          b=long value c=3\n"
+		if wrapWidth == NoWrap {
+			expected = "[]  INFO  test message a=1 b=long value c=3\n"
+		}
+
+		if !bytes.Equal(w.WrittenData, []byte(expected)) {
+			t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+		}
+	}
+}
+
+func testOneLineEscapeNewlines(t *testing.T) {
+	w := &MockWriter{}
+
+	opts := &Options{
+		TimeFormat:     "[]",
+		NoColor:        true,
+		EscapeNewlines: true,
+	}
+
+	logger := slog.New(NewHandler(w, opts))
+
+	type Point struct {
+		X int
+		Y int
+	}
+
+	logger.Info("test\nmessage",
+		slog.String("s", "a\nb"),
+		slog.String("json", "{\n  \"k\": 1\n}"),
+		slog.Any("p", Point{X: 1, Y: 2}),
+	)
+
+	expected := `[]  INFO  test\nmessage s=a\nb json={"k":1} p=humanslog.Point{X=1 Y=2}` + "\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testOneLineTabWidth(t *testing.T) {
+	w := &MockWriter{}
+
+	opts := &Options{
+		TimeFormat: "[]",
+		NoColor:    true,
+		TabWidth:   4,
+	}
+
+	logger := slog.New(NewHandler(w, opts))
+
+	logger.Info("test", slog.String("table", "a\tb\nabcde\tf"))
+
+	expected := "[]  INFO  test table=a   b\nabcde   f\n\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testOneLineMaxAttrs(t *testing.T) {
+	w := &MockWriter{}
+
+	opts := &Options{
+		TimeFormat: "[]",
+		NoColor:    true,
+		MaxAttrs:   2,
+	}
+
+	logger := slog.New(NewHandler(w, opts))
+
+	logger.Info("test", "a", 1, "b", 2, "c", 3, "d", 4)
+
+	expected := "[]  INFO  test a=1 b=2 +2 more\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testOneLineMaxAttrsDebug(t *testing.T) {
+	w := &MockWriter{}
+
+	opts := &Options{
+		HandlerOptions: &slog.HandlerOptions{Level: slog.LevelDebug},
+		TimeFormat:     "[]",
+		NoColor:        true,
+		MaxAttrs:       2,
+	}
+
+	logger := slog.New(NewHandler(w, opts))
+
+	logger.Info("test", "a", 1, "b", 2, "c", 3)
+
+	expected := "[]  INFO  test a=1 b=2 c=3\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testOneLineSlicePrintSizeSentinels(t *testing.T) {
+	for size, expected := range map[uint]string{
+		Unlimited:    "[]  INFO  test s=3 []int{1 2 3}\n",
+		HideElements: "[]  INFO  test s=3 []int{...}\n",
+		2:            "[]  INFO  test s=3 []int{1 2 ...}\n",
+	} {
+		w := &MockWriter{}
+		logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, MaxSlicePrintSize: size}))
+
+		logger.Info("test", "s", []int{1, 2, 3})
+
+		if !bytes.Equal(w.WrittenData, []byte(expected)) {
+			t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+		}
+	}
+}
+
+func testOneLineMapPrintSize(t *testing.T) {
+	m := map[string]int{"c": 3, "a": 1, "d": 4, "b": 2}
+
+	for size, expected := range map[uint]string{
+		Unlimited:    "[]  INFO  test m=4 map[string]int{a=1 b=2 c=3 d=4}\n",
+		HideElements: "[]  INFO  test m=4 map[string]int{...}\n",
+		2:            "[]  INFO  test m=4 map[string]int{a=1 b=2 ... (+2 more)}\n",
+	} {
+		w := &MockWriter{}
+		logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, MaxMapPrintSize: size}))
+
+		logger.Info("test", "m", m)
+
+		if !bytes.Equal(w.WrittenData, []byte(expected)) {
+			t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+		}
+	}
+}
+
+func testOneLineWrapWidthPacked(t *testing.T) {
+	w := &MockWriter{}
+
+	opts := &Options{
+		TimeFormat: "[]",
+		NoColor:    true,
+		WrapWidth:  30,
+	}
+
+	logger := slog.New(NewHandler(w, opts))
+
+	logger.Info("request", "method", "GET", "path", "/users", "status", 200, "bytes", 512)
+
+	expected := "[]  INFO  request method=GET\n" +
+		"          path=/users\n" +
+		"          status=200 bytes=512\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
 // Helper to strip ANSI color codes for testing
 func stripAnsi(s string) string {
 	re := regexp.MustCompile(`\x1b\[[0-9;]*m`)
 	return re.ReplaceAllString(s, "")
 }
+
+func BenchmarkHandleNumbers(b *testing.B) {
+	logger := slog.New(NewHandler(io.Discard, &Options{TimeFormat: "[]"}))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.Info("request", slog.Int("status", 200), slog.Float64("ratio", 0.25), slog.Bool("cached", true), slog.Uint64("bytes", 123456))
+	}
+}
+
+func BenchmarkHandleAttrs(b *testing.B) {
+	logger := slog.New(NewHandler(io.Discard, &Options{TimeFormat: "[]"}))
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.LogAttrs(ctx, slog.LevelInfo, "request", slog.Int("status", 200), slog.String("path", "users"), slog.Bool("cached", true))
+	}
+}
+
+func BenchmarkHandleCollections(b *testing.B) {
+	logger := slog.New(NewHandler(io.Discard, &Options{TimeFormat: "[]"}))
+	ids := []int{1001, 1002, 1003, 1004, 1005}
+	counts := map[string]int{"a": 1000, "b": 2000, "c": 3000}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.Info("batch", slog.Any("ids", ids), slog.Any("counts", counts))
+	}
+}
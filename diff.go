@@ -0,0 +1,499 @@
+package humanslog
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DiffValue holds two versions of a value, rendered as a field-level diff
+type DiffValue struct {
+	Old any
+	New any
+}
+
+// Diff returns a value rendered as a field-level diff of before and after, e.g. slog.Any("user", humanslog.Diff(before, after))
+func Diff(before, after any) DiffValue {
+	return DiffValue{Old: before, New: after}
+}
+
+// Max nesting depth when flattening values for diff
+const maxDiffDepth = 10
+
+// pairDiffAttrs replaces top-level "before" and "after" attributes with a single diff attribute
+// and groups of only "old" and "new" attributes with their diff
+func (h *Handler) pairDiffAttrs(as attributes) attributes {
+	if !h.opts.DiffBeforeAfter {
+		return as
+	}
+
+	as = diffOldNewGroups(as)
+
+	bi, ai := -1, -1
+	for i, a := range as {
+		switch a.Key {
+		case "before":
+			bi = i
+		case "after":
+			ai = i
+		}
+	}
+
+	if bi < 0 || ai < 0 {
+		return as
+	}
+
+	paired := make(attributes, 0, len(as)-1)
+	for i, a := range as {
+		switch i {
+		case bi:
+			paired = append(paired, slog.Any("before/after", Diff(attrDiffValue(a), attrDiffValue(as[ai]))))
+		case ai:
+		default:
+			paired = append(paired, a)
+		}
+	}
+
+	return paired
+}
+
+// diffOldNewGroups returns as with groups of only "old" and "new" attributes replaced by a diff, at any depth
+func diffOldNewGroups(as []slog.Attr) []slog.Attr {
+	var replaced []slog.Attr
+	for i, a := range as {
+		if a.Value.Kind() != slog.KindGroup {
+			continue
+		}
+
+		ga := a.Value.Group()
+		var diffed slog.Attr
+		if len(ga) == 2 && ga[0].Key == "old" && ga[1].Key == "new" {
+			diffed = slog.Any(a.Key, Diff(attrDiffValue(ga[0]), attrDiffValue(ga[1])))
+		} else if nested := diffOldNewGroups(ga); len(ga) > 0 && &nested[0] != &ga[0] {
+			diffed = slog.Attr{Key: a.Key, Value: slog.GroupValue(nested...)}
+		} else {
+			continue
+		}
+
+		// as may be shared with the record or the handler, so it's copied before the first change
+		if replaced == nil {
+			replaced = slices.Clone(as)
+		}
+		replaced[i] = diffed
+	}
+
+	if replaced == nil {
+		return as
+	}
+
+	return replaced
+}
+
+func attrDiffValue(a slog.Attr) any {
+	if a.Value.Kind() == slog.KindGroup {
+		m := make(map[string]any, len(a.Value.Group()))
+		for _, ga := range a.Value.Group() {
+			m[ga.Key] = attrDiffValue(ga)
+		}
+
+		return m
+	}
+
+	return a.Value.Any()
+}
+
+// diffOperand parses JSON strings, so they are compared by fields instead of as one value
+func diffOperand(v any) any {
+	s, ok := v.(string)
+	if !ok {
+		return v
+	}
+
+	var parsed any
+	if err := json.Unmarshal([]byte(s), &parsed); err != nil {
+		return v
+	}
+
+	return parsed
+}
+
+// formatDiff renders changed, added and removed fields of d, one per line
+func (h *Handler) formatDiff(d DiffValue, l int) []byte {
+	d = DiffValue{Old: diffOperand(d.Old), New: diffOperand(d.New)}
+	if h.opts.UnifiedDiff {
+		if b, ok := h.formatLineDiff(d, l); ok {
+			return b
+		}
+	}
+
+	before := make(map[string]string)
+	after := make(map[string]string)
+	h.flattenValue("", reflect.ValueOf(d.Old), before, 0)
+	h.flattenValue("", reflect.ValueOf(d.New), after, 0)
+
+	paths := make([]string, 0, len(before)+len(after))
+	for p := range before {
+		paths = append(paths, p)
+	}
+	for p := range after {
+		if _, ok := before[p]; !ok {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+
+	var lines [][]byte
+	for _, p := range paths {
+		ov, inOld := before[p]
+		nv, inNew := after[p]
+		path := p
+
+		if p != "" {
+			p += ": "
+		}
+
+		switch {
+		case !inOld:
+			lines = append(lines, h.colorString([]byte("+ "+p+h.maskDiffValue(path, nv)), fgGreen))
+		case !inNew:
+			lines = append(lines, h.colorString([]byte("- "+p+h.maskDiffValue(path, ov)), fgRed))
+		case ov != nv:
+			lines = append(lines, h.colorString([]byte("~ "+p+h.maskDiffValue(path, ov)+h.glyphs(" → ")+h.maskDiffValue(path, nv)), fgYellow))
+		}
+	}
+
+	if len(lines) == 0 {
+		return h.colorStringFainted([]byte("no changes"), fgWhite)
+	}
+
+	b := h.colorString([]byte(strconv.Itoa(len(lines))), fgCyan)
+	b = append(b, plural(len(lines), " change")...)
+	for _, line := range lines {
+		b = append(b, '\n')
+		b = append(b, bytes.Repeat([]byte(" "), l*2+4)...)
+		b = append(b, line...)
+	}
+
+	return b
+}
+
+// flattenValue collects leaf values of v keyed by their dotted path
+func (h *Handler) flattenValue(path string, v reflect.Value, out map[string]string, depth int) {
+	if !v.IsValid() {
+		out[path] = "<nil>"
+		return
+	}
+
+	if depth > maxDiffDepth {
+		out[path] = "..."
+		return
+	}
+
+	if v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	// Methods with pointer receivers would dereference nil
+	if (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+		out[path] = "<nil>"
+		return
+	}
+
+	if v.CanInterface() {
+		switch vv := v.Interface().(type) {
+		case error:
+			out[path] = string(h.errorText(vv))
+			return
+		case fmt.Stringer:
+			if h.opts.StringerFormatter || v.Kind() != reflect.Struct || isOpaqueStruct(v) {
+				out[path] = string(h.stringerText(vv))
+				return
+			}
+		case encoding.TextMarshaler:
+			if isOpaqueStruct(v) {
+				out[path] = string(h.marshalText(vv))
+				return
+			}
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			out[path] = "<nil>"
+			return
+		}
+
+		h.flattenValue(path, v.Elem(), out, depth+1)
+	case reflect.Struct:
+		if isOpaqueStruct(v) {
+			out[path] = fmt.Sprint(v)
+			return
+		}
+
+		for _, f := range structFields(v) {
+			if f.redact {
+				out[joinDiffPath(path, f.name)] = h.redactReplacement()
+				continue
+			}
+
+			h.flattenValue(joinDiffPath(path, f.name), f.value, out, depth+1)
+		}
+	case reflect.Map:
+		for _, k := range h.sortMapKeys(v) {
+			h.flattenValue(joinDiffPath(path, fmt.Sprint(k.Interface())), v.MapIndex(k), out, depth+1)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			h.flattenValue(path+"["+strconv.Itoa(i)+"]", v.Index(i), out, depth+1)
+		}
+	default:
+		out[path] = fmt.Sprint(v)
+	}
+}
+
+// maskDiffValue applies Redact and MaskSecrets to a flattened value, keyed by the segments of its path.
+// Values are compared before they are masked, so a changed secret is reported without showing it.
+func (h *Handler) maskDiffValue(path string, v string) string {
+	segments := strings.Split(path, ".")
+	for _, s := range segments {
+		if h.redactKey(strings.SplitN(s, "[", 2)[0]) {
+			return h.redactReplacement()
+		}
+	}
+
+	return h.maskNestedSecrets(h.redactString(v), segments)
+}
+
+// isOpaqueStruct reports whether v is a struct with only unexported fields, e.g. time.Time or netip.Addr,
+// it's compared as one value as its changes aren't visible in fields
+func isOpaqueStruct(v reflect.Value) bool {
+	if v.Kind() != reflect.Struct {
+		return false
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return false
+		}
+	}
+
+	return t.NumField() > 0
+}
+
+func joinDiffPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+// isUnifiedDiff checks if a multiline string looks like a unified diff (---, +++ and @@ headers)
+func isUnifiedDiff(s string) bool {
+	return (strings.HasPrefix(s, "--- ") || strings.Contains(s, "\n--- ")) &&
+		strings.Contains(s, "\n+++ ") &&
+		strings.Contains(s, "\n@@ ")
+}
+
+// formatUnifiedDiff colors added lines green and removed lines red, lines after the first are prefixed with indent
+func (h *Handler) formatUnifiedDiff(s string, indent string) []byte {
+	var b []byte
+	for i, line := range strings.Split(s, "\n") {
+		if i > 0 {
+			b = append(b, '\n')
+			b = append(b, indent...)
+		}
+
+		switch {
+		case strings.HasPrefix(line, "+++ "), strings.HasPrefix(line, "--- "):
+			b = append(b, h.colorString([]byte(line), fgWhite)...)
+		case strings.HasPrefix(line, "@@"):
+			b = append(b, h.colorString([]byte(line), fgCyan)...)
+		case strings.HasPrefix(line, "+"):
+			b = append(b, h.colorString([]byte(line), fgGreen)...)
+		case strings.HasPrefix(line, "-"):
+			b = append(b, h.colorString([]byte(line), fgRed)...)
+		default:
+			b = append(b, line...)
+		}
+	}
+
+	return b
+}
+
+// plural returns s with an "s" appended unless n is 1
+func plural(n int, s string) string {
+	if n == 1 {
+		return s
+	}
+
+	return s + "s"
+}
+
+// Max lines of each value compared by formatLineDiff, larger values use the field-level diff
+const maxLineDiffLines = 1000
+
+// Unchanged lines shown around changed lines by formatLineDiff
+const lineDiffContext = 2
+
+// formatLineDiff renders a unified diff of the indented JSON representations of d,
+// it reports false when a value can't be represented as JSON or is too large
+// or when the values differ only in what JSON doesn't show, e.g. unexported fields
+func (h *Handler) formatLineDiff(d DiffValue, l int) (b []byte, ok bool) {
+	// a panic of MarshalJSON is rendered by recoverFormatter, which runs first
+	defer func() {
+		ok = ok || b != nil
+	}()
+	defer h.recoverFormatter(&b)
+
+	oldJSON, oldMasked, err := h.diffJSON(d.Old)
+	if err != nil {
+		return nil, false
+	}
+	newJSON, newMasked, err := h.diffJSON(d.New)
+	if err != nil {
+		return nil, false
+	}
+	if !bytes.Equal(oldJSON, newJSON) && bytes.Equal(oldMasked, newMasked) {
+		// only masked values changed, the field-level diff reports them
+		return nil, false
+	}
+	if bytes.Equal(oldJSON, newJSON) && !reflect.DeepEqual(d.Old, d.New) {
+		// the changes aren't visible in JSON, the field-level diff prints such values with %v
+		return nil, false
+	}
+	oldJSON, newJSON = oldMasked, newMasked
+
+	before := strings.Split(string(oldJSON), "\n")
+	after := strings.Split(string(newJSON), "\n")
+	if len(before) > maxLineDiffLines || len(after) > maxLineDiffLines {
+		return nil, false
+	}
+
+	ops := diffLines(before, after)
+
+	changes := 0
+	for _, op := range ops {
+		if op.kind != ' ' {
+			changes++
+		}
+	}
+	if changes == 0 {
+		return h.colorStringFainted([]byte("no changes"), fgWhite), true
+	}
+
+	b = h.colorString([]byte(strconv.Itoa(changes)), fgCyan)
+	b = append(b, plural(changes, " changed line")...)
+
+	indent := bytes.Repeat([]byte(" "), l*2+4)
+	skipped := false
+	for i, op := range ops {
+		if op.kind == ' ' && !nearLineChange(ops, i) {
+			skipped = true
+			continue
+		}
+
+		if skipped {
+			b = append(b, '\n')
+			b = append(b, indent...)
+			b = append(b, h.faintedText([]byte(h.glyphs("…")))...)
+			skipped = false
+		}
+
+		b = append(b, '\n')
+		b = append(b, indent...)
+		switch op.kind {
+		case '-':
+			b = append(b, h.colorString([]byte("- "+op.line), fgRed)...)
+		case '+':
+			b = append(b, h.colorString([]byte("+ "+op.line), fgGreen)...)
+		default:
+			b = append(b, h.faintedText([]byte("  "+op.line))...)
+		}
+	}
+
+	return b, true
+}
+
+// diffJSON returns v as JSON and as indented JSON with Redact and MaskSecrets applied
+func (h *Handler) diffJSON(v any) ([]byte, []byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(h.maskJSONSecrets(h.redactString(string(b)), nil)), "", "  "); err != nil {
+		return nil, nil, err
+	}
+
+	return b, buf.Bytes(), nil
+}
+
+type lineDiffOp struct {
+	kind byte
+	line string
+}
+
+// diffLines returns the edit script turning before into after based on their longest common subsequence
+func diffLines(before, after []string) []lineDiffOp {
+	// lcs[i][j] is the length of the longest common subsequence of before[i:] and after[j:]
+	lcs := make([][]int, len(before)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(after)+1)
+	}
+	for i := len(before) - 1; i >= 0; i-- {
+		for j := len(after) - 1; j >= 0; j-- {
+			if before[i] == after[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]lineDiffOp, 0, max(len(before), len(after)))
+	i, j := 0, 0
+	for i < len(before) && j < len(after) {
+		switch {
+		case before[i] == after[j]:
+			ops = append(ops, lineDiffOp{' ', before[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, lineDiffOp{'-', before[i]})
+			i++
+		default:
+			ops = append(ops, lineDiffOp{'+', after[j]})
+			j++
+		}
+	}
+	for ; i < len(before); i++ {
+		ops = append(ops, lineDiffOp{'-', before[i]})
+	}
+	for ; j < len(after); j++ {
+		ops = append(ops, lineDiffOp{'+', after[j]})
+	}
+
+	return ops
+}
+
+// nearLineChange reports whether a changed line is within lineDiffContext lines of ops[i]
+func nearLineChange(ops []lineDiffOp, i int) bool {
+	for j := max(i-lineDiffContext, 0); j <= min(i+lineDiffContext, len(ops)-1); j++ {
+		if ops[j].kind != ' ' {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,48 @@
+package humanslog
+
+import (
+	"strings"
+	"time"
+)
+
+// DurationThresholds are the durations from which a duration is colored yellow and red, shorter durations are green
+type DurationThresholds struct {
+	Yellow time.Duration
+	Red    time.Duration
+}
+
+// Thresholds of Elapsed values and of durations colored with ColorDurations
+var defaultDurationThresholds = DurationThresholds{Yellow: 100 * time.Millisecond, Red: time.Second}
+
+func (t DurationThresholds) color(d time.Duration) foregroundColor {
+	switch {
+	case d >= t.Red:
+		return fgRed
+	case d >= t.Yellow:
+		return fgYellow
+	default:
+		return fgGreen
+	}
+}
+
+// durationColor returns the color of a duration attribute, the thresholds of the longest
+// matching suffix in DurationThresholds take precedence over ColorDurations
+func (h *Handler) durationColor(key string, d time.Duration) (foregroundColor, bool) {
+	lower := strings.ToLower(key)
+	suffix := ""
+	var thresholds DurationThresholds
+	for s, t := range h.opts.DurationThresholds {
+		if len(s) > len(suffix) && strings.HasSuffix(lower, strings.ToLower(s)) {
+			suffix, thresholds = s, t
+		}
+	}
+	if suffix != "" {
+		return thresholds.color(d), true
+	}
+
+	if h.opts.ColorDurations {
+		return defaultDurationThresholds.color(d), true
+	}
+
+	return fgWhite, false
+}
@@ -0,0 +1,61 @@
+package humanslog
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func Test_Durations(t *testing.T) {
+	testColorDurations(t)
+	testDurationThresholds(t)
+	testDurationsUncolored(t)
+}
+
+func testColorDurations(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", ColorDurations: true}))
+
+	logger.Info("msg", slog.Duration("a", 50*time.Millisecond), slog.Duration("b", 500*time.Millisecond), slog.Duration("c", 2*time.Second))
+
+	expected := "\x1b[2m[]\x1b[0m \x1b[42m\x1b[30m INFO \x1b[0m msg " +
+		"\x1b[90ma=\x1b[0m\x1b[32m50ms\x1b[0m \x1b[90mb=\x1b[0m\x1b[33m500ms\x1b[0m \x1b[90mc=\x1b[0m\x1b[31m2s\x1b[0m\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testDurationThresholds(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{
+		TimeFormat:     "[]",
+		ColorDurations: true,
+		DurationThresholds: map[string]DurationThresholds{
+			"_time":      {Yellow: time.Millisecond, Red: 10 * time.Millisecond},
+			"cache_time": {Yellow: 10 * time.Microsecond, Red: 100 * time.Microsecond},
+		},
+	}))
+
+	logger.Info("msg", slog.Duration("db_time", 5*time.Millisecond), slog.Duration("cache_time", 5*time.Millisecond), slog.Any("elapsed_time", Elapsed(50*time.Millisecond)))
+
+	expected := "\x1b[2m[]\x1b[0m \x1b[42m\x1b[30m INFO \x1b[0m msg " +
+		"\x1b[90mdb_time=\x1b[0m\x1b[33m5ms\x1b[0m \x1b[90mcache_time=\x1b[0m\x1b[31m5ms\x1b[0m \x1b[90melapsed_time=\x1b[0m\x1b[31m50ms\x1b[0m\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testDurationsUncolored(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]"}))
+
+	logger.Info("msg", slog.Duration("d", 2*time.Second))
+
+	expected := "\x1b[2m[]\x1b[0m \x1b[42m\x1b[30m INFO \x1b[0m msg \x1b[90md=\x1b[0m\x1b[37m2s\x1b[0m\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
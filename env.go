@@ -0,0 +1,40 @@
+package humanslog
+
+import "os"
+
+type colorEnv int
+
+const (
+	colorEnvDefault colorEnv = iota
+	// colorEnvOff disables colors
+	colorEnvOff
+	// colorEnvForce keeps colors even when the output isn't a terminal
+	colorEnvForce
+)
+
+// colorEnvironment reads the NO_COLOR (https://no-color.org), CLICOLOR, CLICOLOR_FORCE and FORCE_COLOR conventions,
+// NO_COLOR takes precedence over forcing colors
+func colorEnvironment() colorEnv {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("CLICOLOR") == "0" {
+		return colorEnvOff
+	}
+
+	if envEnabled("FORCE_COLOR") || envEnabled("CLICOLOR_FORCE") {
+		return colorEnvForce
+	}
+
+	return colorEnvDefault
+}
+
+func envEnabled(key string) bool {
+	v := os.Getenv(key)
+	return v != "" && v != "0" && v != "false"
+}
+
+func (o *Options) colorEnvironment() colorEnv {
+	if o.IgnoreEnv {
+		return colorEnvDefault
+	}
+
+	return colorEnvironment()
+}
@@ -0,0 +1,94 @@
+package humanslog
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func Test_Env(t *testing.T) {
+	testNoColorEnv(t)
+	testCLIColorEnv(t)
+	testNoColorEnvIgnored(t)
+	testForceColorEnv(t)
+	testForceColorEnvAutoHandler(t)
+	testNoColorEnvWithOptions(t)
+}
+
+// setColorEnv clears the color variables and sets the given key value pairs until the test ends
+func setColorEnv(t *testing.T, kv ...string) {
+	for _, key := range []string{"NO_COLOR", "CLICOLOR", "CLICOLOR_FORCE", "FORCE_COLOR"} {
+		t.Setenv(key, "")
+	}
+
+	for i := 0; i+1 < len(kv); i += 2 {
+		t.Setenv(kv[i], kv[i+1])
+	}
+}
+
+func testNoColorEnv(t *testing.T) {
+	setColorEnv(t, "NO_COLOR", "1", "FORCE_COLOR", "1")
+
+	if h := NewHandler(&MockWriter{}, nil); !h.opts.NoColor {
+		t.Error("Expected NO_COLOR to disable colors")
+	}
+}
+
+func testCLIColorEnv(t *testing.T) {
+	setColorEnv(t, "CLICOLOR", "0")
+
+	if h := NewHandler(&MockWriter{}, nil); !h.opts.NoColor {
+		t.Error("Expected CLICOLOR=0 to disable colors")
+	}
+}
+
+func testNoColorEnvIgnored(t *testing.T) {
+	setColorEnv(t, "NO_COLOR", "1")
+
+	if h := NewHandler(&MockWriter{}, &Options{IgnoreEnv: true}); h.opts.NoColor {
+		t.Error("Expected NO_COLOR to be ignored")
+	}
+}
+
+func testForceColorEnv(t *testing.T) {
+	setColorEnv(t, "FORCE_COLOR", "1")
+
+	if h := NewHandler(&MockWriter{}, &Options{AutoDetectTTY: true}); h.opts.NoColor {
+		t.Error("Expected FORCE_COLOR to keep colors of a non-terminal writer")
+	}
+
+	if h := NewHandler(&MockWriter{}, &Options{NoColor: true}); !h.opts.NoColor {
+		t.Error("Expected FORCE_COLOR not to override NoColor")
+	}
+
+	setColorEnv(t, "FORCE_COLOR", "0")
+
+	if h := NewHandler(&MockWriter{}, &Options{AutoDetectTTY: true}); !h.opts.NoColor {
+		t.Error("Expected FORCE_COLOR=0 not to force colors")
+	}
+}
+
+func testForceColorEnvAutoHandler(t *testing.T) {
+	setColorEnv(t, "CLICOLOR_FORCE", "1")
+
+	w := &MockWriter{}
+	h, ok := NewAutoHandler(w, nil, slog.NewJSONHandler(w, nil)).(*Handler)
+	if !ok {
+		t.Fatal("Expected humanslog handler with CLICOLOR_FORCE")
+	}
+
+	if h.opts.NoColor {
+		t.Error("Expected colors with CLICOLOR_FORCE")
+	}
+}
+
+func testNoColorEnvWithOptions(t *testing.T) {
+	setColorEnv(t, "NO_COLOR", "1")
+
+	h := NewHandler(&MockWriter{}, &Options{NoColor: true}).WithOptions(func(o *Options) {
+		o.NoColor = false
+	})
+
+	if !h.opts.NoColor || h.progress != nil {
+		t.Error("Expected NO_COLOR to disable colors of WithOptions")
+	}
+}
@@ -0,0 +1,81 @@
+package humanslog
+
+import (
+	"log/slog"
+	"strings"
+)
+
+// isErrorAttr reports whether the attribute holds an error or is keyed "err" or "error"
+func isErrorAttr(a slog.Attr) bool {
+	if a.Key == "err" || a.Key == "error" {
+		return a.Value.Kind() != slog.KindGroup
+	}
+
+	if a.Value.Kind() != slog.KindAny {
+		return false
+	}
+
+	_, ok := a.Value.Any().(error)
+	return ok
+}
+
+// splitErrorAttrs moves top-level error attributes out of as
+func splitErrorAttrs(as attributes) (attributes, attributes) {
+	var rest, errs attributes
+	for _, a := range as {
+		if isErrorAttr(a) {
+			errs = append(errs, a)
+		} else {
+			rest = append(rest, a)
+		}
+	}
+
+	return rest, errs
+}
+
+// appendErrorBlock renders errors beneath the record line, wrapped errors follow with ↳ markers
+func (h *Handler) appendErrorBlock(b []byte, as attributes) []byte {
+	if len(as) == 0 {
+		return b
+	}
+
+	if len(b) > 0 && b[len(b)-1] != '\n' {
+		b = append(b, '\n')
+	}
+
+	for _, a := range as {
+		if h.opts.ReplaceAttr != nil {
+			a = h.opts.ReplaceAttr(nil, a)
+			if a.Key == "" {
+				continue
+			}
+		}
+
+		var lines []errorTreeLine
+		if err, ok := a.Value.Any().(error); ok && a.Value.Kind() == slog.KindAny {
+			lines = h.errorLines(err)
+		} else {
+			lines = []errorTreeLine{{text: a.Value.String()}}
+		}
+		if len(lines) == 0 {
+			continue
+		}
+
+		b = append(b, "  "...)
+		b = append(b, h.colorString([]byte(a.Key+": "+h.errorLine(lines[0].text, "    ")), fgRed)...)
+		b = append(b, '\n')
+		for _, l := range lines[1:] {
+			indent := strings.Repeat("  ", l.depth+1)
+			b = append(b, indent...)
+			b = append(b, h.colorString([]byte(h.glyphs(l.marker)+" "+h.errorLine(l.text, indent+"  ")), fgRed)...)
+			b = append(b, '\n')
+		}
+	}
+
+	return b
+}
+
+// errorLine indents the lines of multiline error messages
+func (h *Handler) errorLine(s string, indent string) string {
+	return strings.ReplaceAll(h.expandTabs(s), "\n", "\n"+indent)
+}
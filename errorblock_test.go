@@ -0,0 +1,87 @@
+package humanslog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+)
+
+func Test_ErrorBlock(t *testing.T) {
+	testErrorBlock(t)
+	testErrorBlockErrKey(t)
+	testErrorBlockColored(t)
+	testErrorBlockOneLine(t)
+	testErrorBlockNilPointer(t)
+}
+
+func testErrorBlock(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, ErrorBlock: true}))
+
+	err := fmt.Errorf("save user: %w", fmt.Errorf("query: %w", errors.New("connection refused")))
+	logger.Error("request failed", "user", "ann", "cause", err)
+
+	expected := "[]  ERROR  request failed user=ann\n  cause: save user\n    ↳ query\n    ↳ connection refused\n\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testErrorBlockErrKey(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, ErrorBlock: true}))
+
+	logger.Warn("retrying", "err", "timeout", "attempt", 2)
+
+	expected := "[]  WARN  retrying attempt=2\n  err: timeout\n\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testErrorBlockColored(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", ErrorBlock: true}))
+
+	logger.Error("failed", "err", fmt.Errorf("outer: %w", errors.New("inner")))
+
+	expected := "\x1b[2m[]\x1b[0m \x1b[41m\x1b[30m ERROR \x1b[0m failed\n  \x1b[31merr: outer\x1b[0m\n    \x1b[31m↳ inner\x1b[0m\n\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testErrorBlockOneLine(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, ErrorBlock: true, Format: FormatOneLine}))
+
+	logger.Error("failed", "err", errors.New("boom"))
+
+	expected := "[]  ERROR  failed err=boom\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+type errorBlockPointerError struct{ msg string }
+
+func (e *errorBlockPointerError) Error() string { return e.msg }
+
+func testErrorBlockNilPointer(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, ErrorBlock: true}))
+
+	logger.Error("failed", "err", (*errorBlockPointerError)(nil))
+
+	expected := "[]  ERROR  failed\n  err: !PANIC in formatter: runtime error: invalid memory address or nil pointer dereference\n\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
@@ -0,0 +1,150 @@
+package humanslog
+
+import (
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+)
+
+type errorTreeLine struct {
+	depth  int
+	marker string
+	text   string
+}
+
+type unwrapMultiple interface {
+	Unwrap() []error
+}
+
+// errorLines returns err with its causes following with ↳ markers, errors
+// unwrapping to multiple errors are rendered as bullets with ErrorTree. A panic is rendered as the only line.
+func (h *Handler) errorLines(err error) (lines []errorTreeLine) {
+	var panicked []byte
+	defer func() {
+		if panicked != nil {
+			lines = []errorTreeLine{{text: string(panicked)}}
+		}
+	}()
+	defer h.recoverFormatter(&panicked)
+
+	if !h.opts.ErrorTree {
+		messages := errorMessages(err)
+		lines = make([]errorTreeLine, len(messages))
+		for i, m := range messages {
+			lines[i] = errorTreeLine{text: m}
+			if i > 0 {
+				lines[i].depth, lines[i].marker = 1, "↳"
+			}
+		}
+
+		return lines
+	}
+
+	return errorTreeLines(err, 0, "")
+}
+
+// errorTreeLines renders a chain of wrapped errors flat beneath its first message,
+// errors of errors.Join are nested one level deeper
+func errorTreeLines(err error, depth int, marker string) []errorTreeLine {
+	var lines []errorTreeLine
+	add := func(text string) {
+		lines = append(lines, errorTreeLine{depth: depth, marker: marker, text: text})
+		if len(lines) == 1 {
+			depth, marker = depth+1, "↳"
+		}
+	}
+
+	for err != nil {
+		if e, ok := err.(unwrapMultiple); ok {
+			errs := e.Unwrap()
+			add(multipleErrorHeader(err, errs))
+			childDepth := lines[len(lines)-1].depth + 1
+			for _, inner := range errs {
+				if inner != nil {
+					lines = append(lines, errorTreeLines(inner, childDepth, "•")...)
+				}
+			}
+
+			return lines
+		}
+
+		ue := errors.Unwrap(err)
+		if ue == nil {
+			add(err.Error())
+			return lines
+		}
+
+		msg := err.Error()
+		msg, _ = strings.CutSuffix(msg, ue.Error())
+		msg, _ = strings.CutSuffix(msg, ": ")
+		if msg != "" {
+			add(msg)
+		}
+		err = ue
+	}
+
+	return lines
+}
+
+// multipleErrorHeader is the message of err unless it only repeats the messages of errs, as for errors.Join
+func multipleErrorHeader(err error, errs []error) string {
+	messages := make([]string, 0, len(errs))
+	for _, e := range errs {
+		if e != nil {
+			messages = append(messages, e.Error())
+		}
+	}
+
+	if msg := err.Error(); msg != strings.Join(messages, "\n") {
+		return msg
+	}
+
+	return strconv.Itoa(len(messages)) + " errors"
+}
+
+// isErrorTree reports whether the error is rendered as a tree, in the multiline section
+func (h *Handler) isErrorTree(err error) (tree bool) {
+	if !h.opts.ErrorTree {
+		return false
+	}
+
+	// Unwrap of a nil pointer may panic, the error is then rendered inline with the panic
+	defer func() {
+		if recover() != nil {
+			tree = false
+		}
+	}()
+
+	for err != nil {
+		if _, ok := err.(unwrapMultiple); ok {
+			return true
+		}
+		err = errors.Unwrap(err)
+	}
+
+	return false
+}
+
+// formatErrorTree renders the error with its causes on separate lines indented by indent
+func (h *Handler) formatErrorTree(err error, indent int) []byte {
+	lines := h.errorLines(err)
+	b := h.colorString([]byte(h.errorLine(lines[0].text, strings.Repeat(" ", indent))), fgRed)
+	for _, l := range lines[1:] {
+		pad := strings.Repeat(" ", indent+l.depth*2)
+		b = append(b, '\n')
+		b = append(b, pad...)
+		b = append(b, h.colorString([]byte(h.glyphs(l.marker)+" "+h.errorLine(l.text, pad+"  ")), fgRed)...)
+	}
+
+	return b
+}
+
+func (h *Handler) attrIsErrorTree(a slog.Attr) bool {
+	if a.Value.Kind() != slog.KindAny {
+		return false
+	}
+
+	err, ok := a.Value.Any().(error)
+	return ok && h.isErrorTree(err)
+}
@@ -0,0 +1,55 @@
+package humanslog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+)
+
+func Test_ErrorTree(t *testing.T) {
+	testErrorTree(t)
+	testErrorTreeSingleChain(t)
+	testErrorTreeBlock(t)
+}
+
+func testErrorTree(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, ErrorTree: true}))
+
+	err := fmt.Errorf("save: %w", errors.Join(errors.New("name empty"), fmt.Errorf("email: %w", errors.New("invalid"))))
+	logger.Error("failed", "user", "ann", "err", err)
+
+	expected := "[]  ERROR  failed user=annE err=save\n    ↳ 2 errors\n      • name empty\n      • email\n        ↳ invalid\n\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testErrorTreeSingleChain(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, ErrorTree: true}))
+
+	logger.Error("failed", "err", fmt.Errorf("save: %w", errors.New("refused")))
+
+	expected := "[]  ERROR  failedE err=save: refused\n\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testErrorTreeBlock(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, ErrorTree: true, ErrorBlock: true}))
+
+	logger.Error("failed", "err", errors.Join(errors.New("a"), errors.New("b")))
+
+	expected := "[]  ERROR  failed\n  err: 2 errors\n    • a\n    • b\n\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
@@ -0,0 +1,55 @@
+package humanslog
+
+import (
+	"reflect"
+	"strings"
+)
+
+// structField is an exported struct field rendered according to its log tag
+type structField struct {
+	name   string
+	value  reflect.Value
+	redact bool
+}
+
+// structFields returns exported fields of sv, honoring `log:"-"` (skip the field), `log:"omitempty"`
+// (skip zero values) and `log:"redact"` (mask the value) tags, flags can be combined: `log:"omitempty,redact"`
+func structFields(sv reflect.Value) []structField {
+	st := sv.Type()
+	fields := make([]structField, 0, sv.NumField())
+	for i := 0; i < sv.NumField(); i++ {
+		f := st.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		sf := structField{name: f.Name, value: sv.Field(i)}
+		skip := false
+		for _, flag := range strings.Split(f.Tag.Get("log"), ",") {
+			switch strings.TrimSpace(flag) {
+			case "-":
+				skip = true
+			case "omitempty":
+				skip = skip || sf.value.IsZero()
+			case "redact":
+				sf.redact = true
+			}
+		}
+
+		if !skip {
+			fields = append(fields, sf)
+		}
+	}
+
+	return fields
+}
+
+// fieldValueRedacted returns the masked value of a field tagged with redact or matching the Redact options,
+// or nil when it isn't masked
+func (h *Handler) fieldValueRedacted(f structField) []byte {
+	if f.redact {
+		return h.colorStringFainted([]byte(h.redactReplacement()), fgWhite)
+	}
+
+	return h.redactedField(f.name)
+}
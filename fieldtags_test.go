@@ -0,0 +1,68 @@
+package humanslog
+
+import (
+	"bytes"
+	"log/slog"
+	"reflect"
+	"testing"
+)
+
+func Test_FieldTags(t *testing.T) {
+	testFieldTags(t)
+	testFieldTagsInline(t)
+	testFieldTagsDiff(t)
+}
+
+type taggedUser struct {
+	Name     string
+	Internal string `log:"-"`
+	Nickname string `log:"omitempty"`
+	Age      int    `log:"omitempty"`
+	Password string `log:"redact"`
+	Token    string `log:"omitempty,redact"`
+}
+
+func testFieldTags(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true}))
+
+	logger.Info("msg", slog.Any("user", taggedUser{Name: "bob", Internal: "x", Age: 3, Password: "secret"}))
+
+	expected := "[]  INFO  msgS user=humanslog.taggedUser\n" +
+		"    Name    : bob\n" +
+		"    Age     : 3\n" +
+		"    Password: [REDACTED]\n" +
+		"\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testFieldTagsInline(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, EscapeNewlines: true}))
+
+	logger.Info("msg", slog.Any("user", taggedUser{Name: "bob", Nickname: "b", Token: "t"}))
+
+	expected := "[]  INFO  msg user=humanslog.taggedUser{Name=bob Nickname=b Password=[REDACTED] Token=[REDACTED]}\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testFieldTagsDiff(t *testing.T) {
+	h := NewHandler(&MockWriter{}, &Options{NoColor: true})
+
+	out := map[string]string{}
+	h.flattenValue("", reflect.ValueOf(taggedUser{Name: "a", Internal: "x", Password: "p"}), out, 0)
+
+	if _, ok := out["Internal"]; ok {
+		t.Errorf("Expected Internal to be skipped, got %v", out)
+	}
+
+	if out["Password"] != "[REDACTED]" {
+		t.Errorf("Expected Password to be redacted, got %v", out)
+	}
+}
@@ -0,0 +1,76 @@
+package humanslog
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"slices"
+)
+
+// matchPattern reports whether the message, or the rendered record with MatchPatternsOnAttrs, matches re
+func (h *Handler) matchPattern(re *regexp.Regexp, msg string, rendered []byte) bool {
+	if re.MatchString(msg) {
+		return true
+	}
+
+	return h.opts.MatchPatternsOnAttrs && re.Match(stripANSI(rendered))
+}
+
+// patternsAllow reports whether a record passes IncludePattern and ExcludePattern
+func (h *Handler) patternsAllow(msg string, rendered []byte) bool {
+	if h.opts.IncludePattern != nil && !h.matchPattern(h.opts.IncludePattern, msg, rendered) {
+		return false
+	}
+
+	if h.opts.ExcludePattern != nil && h.matchPattern(h.opts.ExcludePattern, msg, rendered) {
+		return false
+	}
+
+	return true
+}
+
+// filterAllows reports whether Filter keeps the record, with attributes of the handler and the context
+func (h *Handler) filterAllows(ctx context.Context, r slog.Record) bool {
+	if h.opts.Filter == nil {
+		return true
+	}
+
+	r = h.mergedRecord(r)
+	if attrs := contextAttrs(ctx); len(attrs) > 0 {
+		r = r.Clone()
+		r.AddAttrs(attrs...)
+	}
+
+	return h.opts.Filter(ctx, r)
+}
+
+// mergedRecord returns r with the attributes of WithAttrs and WithGroup, record attributes are nested in the groups
+func (h *Handler) mergedRecord(r slog.Record) slog.Record {
+	if len(h.goas) == 0 {
+		return r
+	}
+
+	attrs := make([]slog.Attr, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+
+	for i := len(h.goas) - 1; i >= 0; i-- {
+		goa := h.goas[i]
+		if goa.group == "" {
+			attrs = append(slices.Clip(goa.attrs), attrs...)
+			continue
+		}
+
+		// empty groups are omitted
+		if len(attrs) > 0 {
+			attrs = []slog.Attr{{Key: goa.group, Value: slog.GroupValue(attrs...)}}
+		}
+	}
+
+	merged := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	merged.AddAttrs(attrs...)
+
+	return merged
+}
@@ -0,0 +1,106 @@
+package humanslog
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"regexp"
+	"testing"
+)
+
+func Test_Filter(t *testing.T) {
+	testIncludePattern(t)
+	testExcludePattern(t)
+	testMatchPatternsOnAttrs(t)
+	testFilterFunc(t)
+	testFilterMergedAttrs(t)
+}
+
+func testIncludePattern(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, IncludePattern: regexp.MustCompile(`^payment`)}))
+
+	logger.Info("payment accepted")
+	logger.Info("user created")
+
+	expected := "[]  INFO  payment accepted\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testExcludePattern(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, ExcludePattern: regexp.MustCompile(`health`)}))
+
+	logger.Info("healthcheck ok")
+	logger.Info("user created", slog.String("path", "/health"))
+
+	expected := "[]  INFO  user created path=/health\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testMatchPatternsOnAttrs(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", ExcludePattern: regexp.MustCompile(`path=/health`), MatchPatternsOnAttrs: true}))
+
+	logger.Info("request", slog.String("path", "/health"))
+	logger.Info("request", slog.String("path", "/users"))
+
+	if bytes.Contains(w.WrittenData, []byte("/health")) || !bytes.Contains(w.WrittenData, []byte("/users")) {
+		t.Errorf("\nExpected only the /users request\nGot:\n%s", w.WrittenData)
+	}
+}
+
+func testFilterFunc(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{
+		TimeFormat: "[]",
+		NoColor:    true,
+		Filter: func(_ context.Context, r slog.Record) bool {
+			keep := true
+			r.Attrs(func(a slog.Attr) bool {
+				if a.Key == "component" && a.Value.String() == "poller" {
+					keep = false
+				}
+				return keep
+			})
+			return keep
+		},
+	}))
+
+	logger.With("component", "poller").Info("tick")
+	logger.Info("tick", "component", "poller")
+	logger.With("component", "api").Info("request")
+
+	expected := "[]  INFO  request component=api\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testFilterMergedAttrs(t *testing.T) {
+	var got []string
+	h := NewHandler(&MockWriter{}, &Options{
+		Filter: func(_ context.Context, r slog.Record) bool {
+			r.Attrs(func(a slog.Attr) bool {
+				got = append(got, a.String())
+				return true
+			})
+			return false
+		},
+	})
+
+	slog.New(h).With("a", 1).WithGroup("g").With("b", 2).WithGroup("empty").Info("msg", "c", 3)
+
+	expected := []string{"a=1", "g=[b=2 empty=[c=3]]"}
+
+	if len(got) != len(expected) || got[0] != expected[0] || got[1] != expected[1] {
+		t.Errorf("Expected merged attributes %v, got %v", expected, got)
+	}
+}
@@ -0,0 +1,18 @@
+package humanslog
+
+// Format is the layout of records, see Options.Format
+type Format int
+
+const (
+	// FormatHybrid renders simple attributes on the record line and multiline values, structs and large groups in an indented section beneath it
+	FormatHybrid Format = iota
+
+	// FormatOneLine renders every record on a single line, newlines in values are escaped
+	FormatOneLine
+
+	// FormatExpanded renders every attribute on its own line beneath the record line, with aligned keys
+	FormatExpanded
+
+	// FormatLogfmt renders strict logfmt without colors like slog.TextHandler, for files and tools like lnav
+	FormatLogfmt
+)
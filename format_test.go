@@ -0,0 +1,130 @@
+package humanslog
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_Format(t *testing.T) {
+	testFormatOneLine(t)
+	testFormatExpanded(t)
+	testFormatLogfmt(t)
+	testFormatLogfmtReplaceAttr(t)
+	testFormatLogfmtNestedSecret(t)
+	testMessagePadding(t)
+}
+
+func testFormatOneLine(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, Format: FormatOneLine}))
+
+	logger.Info("msg", slog.String("s", "a\nb"), slog.Int("n", 1))
+
+	expected := "[]  INFO  msg s=a\\nb n=1\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testFormatExpanded(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, Format: FormatExpanded}))
+
+	logger.Info("msg", slog.Int("n", 1), slog.String("name", "gopher"))
+
+	expected := "[]  INFO  msg\n" +
+		"# n   =1\n" +
+		"  name=gopher\n" +
+		"\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testFormatLogfmt(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{Format: FormatLogfmt}))
+
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	r := slog.NewRecord(ts, slog.LevelWarn, "user \"created\"", 0)
+	r.AddAttrs(
+		slog.String("name", "Rob Pike"),
+		slog.Int("n", 1),
+		slog.String("s", "a\nb"),
+		slog.String("empty", ""),
+		slog.Any("err", errors.New("boom")),
+		slog.Group("req", slog.String("path", "/users"), slog.Group("empty")),
+	)
+	_ = logger.With("svc", "api").Handler().Handle(context.Background(), r)
+
+	expected := `time=2024-01-02T03:04:05.000Z level=WARN msg="user \"created\"" name="Rob Pike" n=1 s="a\nb" empty="" err=boom req.path=/users svc=api` + "\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testFormatLogfmtReplaceAttr(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{
+		Format:       FormatLogfmt,
+		LevelStrings: map[slog.Level]string{slog.LevelInfo: "I"},
+		HandlerOptions: &slog.HandlerOptions{
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if a.Key == slog.TimeKey && len(groups) == 0 {
+					return slog.Attr{}
+				}
+				if a.Key == "secret" {
+					a.Value = slog.StringValue("***")
+				}
+				return a
+			},
+		},
+	}))
+
+	logger.WithGroup("g").Info("msg", "secret", "x")
+
+	expected := "level=I msg=msg g.secret=***\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testFormatLogfmtNestedSecret(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{Format: FormatLogfmt, MaskSecrets: true}))
+
+	logger.Info("msg", "cfg", map[string]any{"api_token": "0123456789abcdef0123456789abcdef"})
+
+	expected := `cfg="{\"api_token\":\"[REDACTED hex-secret]\"}"`
+
+	if !strings.Contains(string(w.WrittenData), expected) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testMessagePadding(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, MessagePadding: 12}))
+
+	logger.Info("started", slog.Int("n", 1))
+	logger.Warn("slow request", slog.Int("n", 2))
+	logger.Info("a very long message", slog.Int("n", 3))
+	logger.Info("no attributes")
+
+	expected := "[]  INFO  started      n=1\n" +
+		"[]  WARN  slow request n=2\n" +
+		"[]  INFO  a very long message n=3\n" +
+		"[]  INFO  no attributes\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
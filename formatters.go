@@ -0,0 +1,69 @@
+package humanslog
+
+import (
+	"log/slog"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// valueFormatter returns the ValueFormatters entry of the attribute, groups are never formatted
+func (h *Handler) valueFormatter(group []string, a slog.Attr) func(v slog.Value) []byte {
+	if len(h.opts.ValueFormatters) == 0 || a.Value.Kind() == slog.KindGroup {
+		return nil
+	}
+
+	if len(group) > 0 {
+		if f, ok := h.opts.ValueFormatters[strings.Join(group, ".")+"."+a.Key]; ok {
+			return f
+		}
+	}
+
+	return h.opts.ValueFormatters[a.Key]
+}
+
+// typeFormatters maps reflect.Type to func(any) []byte
+var typeFormatters sync.Map
+
+// RegisterTypeFormatter sets how all handlers render values of type T and pointers to them, also in structs, slices
+// and maps, e.g. humanslog.RegisterTypeFormatter(func(id uuid.UUID) []byte { return []byte(id.String()) }).
+// It takes precedence over encoding.TextMarshaler and fmt.Stringer, FormatLogfmt doesn't use it. A nil f removes
+// the formatter of T.
+func RegisterTypeFormatter[T any](f func(T) []byte) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if f == nil {
+		typeFormatters.Delete(t)
+		return
+	}
+
+	typeFormatters.Store(t, func(v any) []byte { return f(v.(T)) })
+}
+
+// typeFormatter returns the registered formatter of v's type, or of the element type of a non-nil pointer, and the
+// value to pass to it
+func typeFormatter(v reflect.Value) (func(any) []byte, any, bool) {
+	for v.IsValid() && v.CanInterface() {
+		if f, ok := typeFormatters.Load(v.Type()); ok {
+			return f.(func(any) []byte), v.Interface(), true
+		}
+
+		if v.Kind() != reflect.Pointer || v.IsNil() {
+			break
+		}
+		v = v.Elem()
+	}
+
+	return nil, nil, false
+}
+
+// formatRegisteredType renders v with its registered formatter, a panic is rendered instead of crashing the application
+func (h *Handler) formatRegisteredType(v reflect.Value) (b []byte, ok bool) {
+	f, x, ok := typeFormatter(v)
+	if !ok {
+		return nil, false
+	}
+
+	defer h.recoverFormatter(&b)
+
+	return f(x), true
+}
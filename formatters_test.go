@@ -0,0 +1,120 @@
+package humanslog
+
+import (
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func Test_ValueFormatters(t *testing.T) {
+	testValueFormattersInline(t)
+	testValueFormattersMultiline(t)
+}
+
+func durationMs(v slog.Value) []byte {
+	return []byte((time.Duration(v.Int64()) * time.Millisecond).String())
+}
+
+func testValueFormattersInline(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{
+		TimeFormat: "[]",
+		NoColor:    true,
+		ValueFormatters: map[string]func(slog.Value) []byte{
+			"duration_ms": durationMs,
+			"db.id":       func(v slog.Value) []byte { return []byte("#" + v.String()) },
+		},
+	}))
+
+	logger.Info("query", "duration_ms", 1500, "id", 7, slog.Group("db", "id", 7, "duration_ms", 20))
+
+	expected := "[]  INFO  query duration_ms=1.5s id=7 db.id=#7 db.duration_ms=20ms\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testValueFormattersMultiline(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{
+		TimeFormat: "[]",
+		NoColor:    true,
+		ValueFormatters: map[string]func(slog.Value) []byte{
+			"sql": func(v slog.Value) []byte { return []byte("<query>") },
+		},
+	}))
+
+	logger.Info("query", "sql", "SELECT *\nFROM users")
+
+	expected := "[]  INFO  query sql=<query>\n\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+type testMoney struct{ cents int }
+
+type testID [4]byte
+
+func Test_TypeFormatters(t *testing.T) {
+	RegisterTypeFormatter(func(m testMoney) []byte { return fmt.Appendf(nil, "$%d.%02d", m.cents/100, m.cents%100) })
+	RegisterTypeFormatter(func(id testID) []byte { return fmt.Appendf(nil, "%x", id[:]) })
+	defer RegisterTypeFormatter[testMoney](nil)
+	defer RegisterTypeFormatter[testID](nil)
+
+	testTypeFormattersInline(t)
+	testTypeFormattersNested(t)
+	testTypeFormattersPanic(t)
+}
+
+func testTypeFormattersInline(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true}))
+
+	logger.Info("paid", "id", testID{1, 2, 3, 4}, "total", &testMoney{1250})
+
+	expected := "[]  INFO  paid id=01020304 total=$12.50\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testTypeFormattersNested(t *testing.T) {
+	type order struct {
+		ID    testID
+		Total *testMoney
+	}
+
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, EscapeNewlines: true}))
+
+	logger.Info("paid", "order", order{ID: testID{1}, Total: &testMoney{5}}, "items", []testMoney{{7}})
+
+	expected := "[]  INFO  paid order=humanslog.order{ID=01000000 Total=$0.05} items=1 []humanslog.testMoney{$0.07}\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+type testPanicking struct{}
+
+func testTypeFormattersPanic(t *testing.T) {
+	RegisterTypeFormatter(func(testPanicking) []byte { panic("boom") })
+	defer RegisterTypeFormatter[testPanicking](nil)
+
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true}))
+
+	logger.Info("msg", "v", testPanicking{})
+
+	expected := "[]  INFO  msg v=!PANIC in formatter: boom\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
@@ -0,0 +1,68 @@
+package humanslog
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func Test_GroupTree(t *testing.T) {
+	testGroupTree(t)
+	testGroupTreeWithGroup(t)
+	testGroupTreeReplaceAttrGroups(t)
+}
+
+func testGroupTree(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, GroupTree: true}))
+
+	logger.Info("msg", slog.Int("a", 1), slog.Group("g", slog.Int("b", 2), slog.Group("h", slog.Int("c", 3))))
+
+	expected := "[]  INFO  msg a=1G g=\n" +
+		"  # b=2\n" +
+		"  G h=\n" +
+		"    # c=3\n" +
+		"\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testGroupTreeWithGroup(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, GroupTree: true}))
+
+	logger.With("svc", "api").WithGroup("req").Info("msg", slog.Int("id", 1))
+
+	expected := "[]  INFO  msg svc=apiG req=\n" +
+		"  # id=1\n" +
+		"\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testGroupTreeReplaceAttrGroups(t *testing.T) {
+	var got []string
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{
+		TimeFormat: "[]",
+		NoColor:    true,
+		GroupTree:  true,
+		HandlerOptions: &slog.HandlerOptions{
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if a.Key == "after" {
+					got = groups
+				}
+				return a
+			},
+		},
+	}))
+
+	logger.Info("msg", slog.Group("g", slog.Group("inner", slog.Int("a", 1)), slog.Int("after", 2)))
+
+	if len(got) != 1 || got[0] != "g" {
+		t.Errorf("Expected the attribute after a nested group in group [g], got %v", got)
+	}
+}
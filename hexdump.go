@@ -0,0 +1,102 @@
+package humanslog
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+)
+
+// hexDump is a byte slice rendered as an offset/hex/ASCII dump in the multiline section
+type hexDump []byte
+
+// Hex returns a value rendered as a hex dump block, e.g. logger.Debug("packet", "data", humanslog.Hex(data)).
+// Other handlers get the bytes.
+func Hex(data []byte) slog.Value {
+	return slog.AnyValue(hexDump(data))
+}
+
+// Bytes in one row of a hex dump
+const hexDumpWidth = 16
+
+// hexDumpBytes returns the bytes of Hex values and of non-text []byte values longer than HexDumpOver
+func (h *Handler) hexDumpBytes(v any) ([]byte, bool) {
+	switch d := v.(type) {
+	case hexDump:
+		return d, true
+	case []byte:
+		return d, h.opts.HexDumpOver > 0 && len(d) > h.opts.HexDumpOver && !isText(d)
+	}
+
+	return nil, false
+}
+
+// attrIsHexDump reports whether the attribute or a member of its group is rendered as a hex dump
+func (h *Handler) attrIsHexDump(a slog.Attr) bool {
+	switch a.Value.Kind() {
+	case slog.KindGroup:
+		for _, ga := range a.Value.Group() {
+			if h.attrIsHexDump(ga) {
+				return true
+			}
+		}
+	case slog.KindAny:
+		_, ok := h.hexDumpBytes(a.Value.Any())
+		return ok
+	}
+
+	return false
+}
+
+// formatHexDump renders up to HexDumpRows rows of 16 bytes, each on a new line prefixed with indent
+func (h *Handler) formatHexDump(data []byte, indent string) []byte {
+	rows := h.opts.HexDumpRows
+	if rows == 0 {
+		rows = 16
+	}
+	n := min(len(data), rows*hexDumpWidth)
+
+	var b []byte
+	for off := 0; off < n; off += hexDumpWidth {
+		row := data[off:min(off+hexDumpWidth, n)]
+
+		b = append(b, '\n')
+		b = append(b, indent...)
+		b = append(b, h.faintedText(fmt.Appendf(nil, "%08x", off))...)
+		b = append(b, ' ')
+
+		var hex []byte
+		for i := 0; i < hexDumpWidth; i++ {
+			if i%8 == 0 {
+				hex = append(hex, ' ')
+			}
+			if i < len(row) {
+				hex = append(hex, hexDigits[row[i]>>4], hexDigits[row[i]&0xf], ' ')
+			} else {
+				hex = append(hex, "   "...)
+			}
+		}
+		b = append(b, h.colorString(hex, h.numberColor())...)
+		b = append(b, ' ')
+
+		text := make([]byte, len(row))
+		for i, c := range row {
+			if c < 0x20 || c > 0x7e {
+				c = '.'
+			}
+			text[i] = c
+		}
+		b = append(b, h.colorString([]byte("|"), h.punctColor(fgWhite))...)
+		b = append(b, h.colorString(text, h.stringColor(nil))...)
+		b = append(b, h.colorString([]byte("|"), h.punctColor(fgWhite))...)
+	}
+
+	if n < len(data) {
+		b = append(b, '\n')
+		b = append(b, indent...)
+		b = append(b, h.faintedText([]byte(h.glyphs("… "+strconv.Itoa(len(data)-n)+" more bytes")))...)
+	}
+
+	return b
+}
+
+const hexDigits = "0123456789abcdef"
@@ -0,0 +1,77 @@
+package humanslog
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+)
+
+func Test_HexDump(t *testing.T) {
+	testHexDump(t)
+	testHexDumpRows(t)
+	testHexDumpOver(t)
+	testHexDumpLogfmt(t)
+}
+
+func testHexDump(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true}))
+
+	logger.Info("msg", "data", Hex([]byte("Hello world\n\x00\x01\x02\x03abc")))
+
+	expected := "[]  INFO  msgB data=\n" +
+		"  00000000  48 65 6c 6c 6f 20 77 6f  72 6c 64 0a 00 01 02 03  |Hello world.....|\n" +
+		"  00000010  61 62 63                                          |abc|\n\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testHexDumpRows(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, ASCIIOnly: true, HexDumpRows: 1}))
+
+	logger.Info("msg", "data", Hex(make([]byte, 20)))
+
+	expected := "[]  INFO  msgB data=\n" +
+		"  00000000  00 00 00 00 00 00 00 00  00 00 00 00 00 00 00 00  |................|\n" +
+		"  ... 4 more bytes\n\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testHexDumpOver(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, HexDumpOver: 4}))
+
+	logger.Info("msg", "small", []byte{0xff}, "text", []byte("hello world"), "bin", []byte{1, 2, 3, 4, 0xff})
+
+	expected := "[]  INFO  msg small=ff (1 bytes) text=hello world" +
+		"B bin=\n" +
+		"  00000000  01 02 03 04 ff                                    |.....|\n\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testHexDumpLogfmt(t *testing.T) {
+	w := &MockWriter{}
+	h := NewHandler(w, &Options{Format: FormatLogfmt})
+
+	r := slog.Record{Level: slog.LevelInfo, Message: "msg"}
+	r.AddAttrs(slog.Any("data", Hex([]byte("Hi"))))
+	if err := h.Handle(context.Background(), r); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "level=INFO msg=msg data=4869\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
@@ -0,0 +1,25 @@
+package humanslog
+
+import "regexp"
+
+// HighlightRule styles whole records whose message or attributes match Pattern
+type HighlightRule struct {
+	Pattern *regexp.Regexp
+	Style   Style
+}
+
+// highlightLines styles the lines of b with the first matching highlight rule
+func (h *Handler) highlightLines(b []byte) []byte {
+	if len(h.opts.Highlights) == 0 || h.opts.NoColor {
+		return b
+	}
+
+	plain := stripANSI(b)
+	for _, rule := range h.opts.Highlights {
+		if rule.Pattern != nil && rule.Pattern.Match(plain) {
+			return recolorLines(b, h.styleCode(nil, rule.Style))
+		}
+	}
+
+	return b
+}
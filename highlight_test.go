@@ -0,0 +1,49 @@
+package humanslog
+
+import (
+	"bytes"
+	"log/slog"
+	"regexp"
+	"testing"
+)
+
+func Test_Highlights(t *testing.T) {
+	testHighlightAttr(t)
+	testHighlightNoColor(t)
+}
+
+func testHighlightAttr(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{
+		TimeFormat: "[]",
+		Highlights: []HighlightRule{
+			{Pattern: regexp.MustCompile(`request_id=r-42\b`), Style: Style{Background: Yellow, Emphasis: Bold}},
+		},
+	}))
+
+	logger.Info("msg", "request_id", "r-42")
+
+	expected := "\x1b[1m\x1b[43m\x1b[2m[]\x1b[0m\x1b[1m\x1b[43m \x1b[42m\x1b[30m INFO \x1b[0m\x1b[1m\x1b[43m msg \x1b[90mrequest_id=\x1b[0m\x1b[1m\x1b[43mr-42\x1b[0m\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testHighlightNoColor(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{
+		TimeFormat: "[]",
+		NoColor:    true,
+		Highlights: []HighlightRule{{Pattern: regexp.MustCompile(`r-42`), Style: Style{Emphasis: Bold}}},
+	}))
+
+	logger.Info("msg", "request_id", "r-42")
+	logger.Info("msg", "request_id", "r-7")
+
+	expected := "[]  INFO  msg request_id=r-42\n[]  INFO  msg request_id=r-7\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
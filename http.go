@@ -0,0 +1,218 @@
+package humanslog
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Headers rendered for requests and responses when Options.HTTPHeaders is nil
+var defaultHTTPHeaders = []string{"Content-Type", "Content-Length"}
+
+// Headers whose values are never rendered
+var sensitiveHTTPHeaders = map[string]struct{}{
+	"Authorization":       {},
+	"Proxy-Authorization": {},
+	"Cookie":              {},
+	"Set-Cookie":          {},
+}
+
+func isHTTPValue(v any) bool {
+	switch v := v.(type) {
+	case *http.Request:
+		return v != nil
+	case *http.Response:
+		return v != nil
+	}
+
+	return false
+}
+
+// formatHTTP renders a *http.Request or *http.Response on one line, it reports false for other values,
+// path is the attribute key with its groups, leading to secrets of the body
+func (h *Handler) formatHTTP(v any, path []string) ([]byte, bool) {
+	switch v := v.(type) {
+	case *http.Request:
+		if v == nil {
+			return nil, false
+		}
+
+		return h.formatHTTPRequest(v, path), true
+	case *http.Response:
+		if v == nil {
+			return nil, false
+		}
+
+		return h.formatHTTPResponse(v, path), true
+	}
+
+	return nil, false
+}
+
+func (h *Handler) formatHTTPRequest(r *http.Request, path []string) []byte {
+	b := h.colorString([]byte(r.Method), fgMagenta)
+	if r.URL != nil {
+		// passwords of userinfo are masked
+		u := r.URL.Redacted()
+		if r.URL.Host == "" && r.Host != "" {
+			// server requests only have the path in the URL
+			u = r.Host + u
+		}
+
+		b = append(b, ' ')
+		b = append(b, h.colorString([]byte(u), h.urlColor())...)
+	}
+
+	b = h.appendHTTPHeaders(b, r.Header)
+	if h.opts.HTTPBodySize > 0 {
+		b = h.appendHTTPBody(b, h.peekRequestBody(r), r.ContentLength, path)
+	}
+
+	return b
+}
+
+func (h *Handler) formatHTTPResponse(r *http.Response, path []string) []byte {
+	b := h.colorString([]byte(strconv.Itoa(r.StatusCode)), h.httpStatusColor(r.StatusCode))
+	if text := http.StatusText(r.StatusCode); text != "" {
+		b = append(b, ' ')
+		b = append(b, h.colorString([]byte(text), h.httpStatusColor(r.StatusCode))...)
+	}
+
+	if r.Request != nil {
+		b = append(b, ' ')
+		b = append(b, h.faintedText([]byte(h.glyphs("←")))...)
+		b = append(b, ' ')
+		b = append(b, h.colorString([]byte(r.Request.Method), fgMagenta)...)
+		if r.Request.URL != nil {
+			b = append(b, ' ')
+			b = append(b, h.colorString([]byte(r.Request.URL.Redacted()), h.urlColor())...)
+		}
+	}
+
+	b = h.appendHTTPHeaders(b, r.Header)
+	if h.opts.HTTPBodySize > 0 {
+		b = h.appendHTTPBody(b, h.peekResponseBody(r), r.ContentLength, path)
+	}
+
+	return b
+}
+
+func (h *Handler) httpStatusColor(code int) foregroundColor {
+	switch {
+	case code >= 500:
+		return fgRed
+	case code >= 400:
+		return fgYellow
+	case code >= 300:
+		return fgCyan
+	case code >= 200:
+		return fgGreen
+	}
+
+	return fgWhite
+}
+
+func (h *Handler) appendHTTPHeaders(b []byte, header http.Header) []byte {
+	names := h.opts.HTTPHeaders
+	if names == nil {
+		names = defaultHTTPHeaders
+	}
+
+	for _, name := range names {
+		values := header.Values(name)
+		if len(values) == 0 {
+			continue
+		}
+
+		name = http.CanonicalHeaderKey(name)
+		value := strings.Join(values, ", ")
+		if _, ok := sensitiveHTTPHeaders[name]; ok {
+			value = h.redactReplacement()
+		}
+
+		b = append(b, ' ')
+		b = append(b, h.colorString([]byte(name+"="), h.keyColor())...)
+		b = append(b, h.quoteIfNeeded(value)...)
+	}
+
+	return b
+}
+
+// appendHTTPBody appends the peeked body with Redact and MaskSecrets applied, JSON keys are masked under path and "body"
+func (h *Handler) appendHTTPBody(b []byte, body []byte, length int64, path []string) []byte {
+	if len(body) == 0 {
+		return b
+	}
+
+	b = append(b, ' ')
+	b = append(b, h.colorString([]byte("body="), h.keyColor())...)
+
+	if !utf8.Valid(body) {
+		return append(b, h.faintedText([]byte(strconv.Itoa(len(body))+" bytes binary"))...)
+	}
+
+	s := h.maskJSONSecrets(h.redactString(string(body)), append(path[:len(path):len(path)], "body"))
+	b = append(b, strconv.Quote(s)...)
+	if length > int64(len(body)) {
+		b = append(b, h.faintedText([]byte(h.glyphs(" … ")+strconv.FormatInt(length-int64(len(body)), 10)+" bytes truncated"))...)
+	}
+
+	return b
+}
+
+// quoteIfNeeded quotes s when it contains spaces, quotes or control characters
+func (h *Handler) quoteIfNeeded(s string) []byte {
+	if s == "" || strings.ContainsAny(s, " \"=\t\r\n") {
+		return []byte(strconv.Quote(s))
+	}
+
+	return []byte(s)
+}
+
+// peekRequestBody returns up to HTTPBodySize bytes of the body, using GetBody when it's set so the body is left untouched
+func (h *Handler) peekRequestBody(r *http.Request) []byte {
+	if r.GetBody != nil {
+		body, err := r.GetBody()
+		if err != nil {
+			return nil
+		}
+		defer body.Close()
+
+		b, _ := io.ReadAll(io.LimitReader(body, int64(h.opts.HTTPBodySize)))
+		return b
+	}
+
+	return h.peekBody(r.Body)
+}
+
+// peekResponseBody returns up to HTTPBodySize bytes of a seekable body, the body of 101 Switching Protocols is the connection
+func (h *Handler) peekResponseBody(r *http.Response) []byte {
+	if r.StatusCode == http.StatusSwitchingProtocols {
+		return nil
+	}
+
+	return h.peekBody(r.Body)
+}
+
+// peekBody reads up to HTTPBodySize bytes of a seekable body and seeks back. Other bodies aren't read, they may be streams
+// which block, and replacing them with a replaying reader would hide their other interfaces, e.g. io.WriterTo.
+func (h *Handler) peekBody(body io.Reader) []byte {
+	s, ok := body.(io.ReadSeeker)
+	if !ok {
+		return nil
+	}
+
+	pos, err := s.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil
+	}
+
+	b, _ := io.ReadAll(io.LimitReader(s, int64(h.opts.HTTPBodySize)))
+	if _, err := s.Seek(pos, io.SeekStart); err != nil {
+		return nil
+	}
+
+	return b
+}
@@ -0,0 +1,83 @@
+package humanslog
+
+import (
+	"log/slog"
+	"strconv"
+	"strings"
+)
+
+var (
+	byteUnits  = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+	countUnits = []string{"", "k", "M", "G", "T", "P", "E"}
+)
+
+// humanizeNumber returns n in units of base, with one decimal place below 10 (4.2 MiB, 13 MiB, 1.3k)
+func humanizeNumber(n uint64, base float64, units []string, sep string) string {
+	f := float64(n)
+	i := 0
+	for f >= base && i < len(units)-1 {
+		f /= base
+		i++
+	}
+
+	prec := 0
+	if i > 0 && f < 10 {
+		prec = 1
+	}
+
+	return strconv.FormatFloat(f, 'f', prec, 64) + sep + units[i]
+}
+
+func hasKeySuffix(key string, suffixes []string) bool {
+	lower := strings.ToLower(key)
+	for _, s := range suffixes {
+		if strings.HasSuffix(lower, strings.ToLower(s)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// humanizedValue returns integer values of keys matching ByteSizeKeys or CountKeys in human units
+func (h *Handler) humanizedValue(key string, v slog.Value) (string, bool) {
+	var n uint64
+	switch v.Kind() {
+	case slog.KindInt64:
+		if v.Int64() < 0 {
+			return "", false
+		}
+		n = uint64(v.Int64())
+	case slog.KindUint64:
+		n = v.Uint64()
+	default:
+		return "", false
+	}
+
+	switch {
+	case hasKeySuffix(key, h.opts.ByteSizeKeys):
+		return humanizeNumber(n, 1024, byteUnits, " "), true
+	case hasKeySuffix(key, h.opts.CountKeys) && n >= 1000:
+		return humanizeNumber(n, 1000, countUnits, ""), true
+	default:
+		return "", false
+	}
+}
+
+// appendHumanized appends the humanized value followed by the dimmed raw value when they differ
+func (h *Handler) appendHumanized(b []byte, key string, v slog.Value) ([]byte, bool) {
+	s, ok := h.humanizedValue(key, v)
+	if !ok {
+		return b, false
+	}
+
+	b = h.appendColored(b, []byte(s), h.numberColor())
+
+	raw := appendValue(nil, v)
+	if s != string(raw)+" B" {
+		b = append(b, ' ')
+		b = h.appendColored(b, append(append([]byte("("), raw...), ')'), faintColor)
+	}
+
+	return b, true
+}
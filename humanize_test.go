@@ -0,0 +1,65 @@
+package humanslog
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func Test_Humanize(t *testing.T) {
+	testHumanizeNumber(t)
+	testHumanizedAttrs(t)
+	testHumanizedAttrsColor(t)
+}
+
+func testHumanizeNumber(t *testing.T) {
+	tests := []struct {
+		n        uint64
+		base     float64
+		units    []string
+		sep      string
+		expected string
+	}{
+		{512, 1024, byteUnits, " ", "512 B"},
+		{4404019, 1024, byteUnits, " ", "4.2 MiB"},
+		{13 << 20, 1024, byteUnits, " ", "13 MiB"},
+		{1342, 1000, countUnits, "", "1.3k"},
+		{250000, 1000, countUnits, "", "250k"},
+	}
+
+	for _, tt := range tests {
+		if got := humanizeNumber(tt.n, tt.base, tt.units, tt.sep); got != tt.expected {
+			t.Errorf("humanizeNumber(%d): expected %q, got %q", tt.n, tt.expected, got)
+		}
+	}
+}
+
+func testHumanizedAttrs(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{
+		TimeFormat:   "[]",
+		NoColor:      true,
+		ByteSizeKeys: []string{"_bytes", "size"},
+		CountKeys:    []string{"len"},
+	}))
+
+	logger.Info("upload", "body_bytes", 4404019, "size", 100, "queue_len", 1342, "items_len", 12, "id", 4404019)
+
+	expected := "[]  INFO  upload body_bytes=4.2 MiB (4404019) size=100 B queue_len=1.3k (1342) items_len=12 id=4404019\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testHumanizedAttrsColor(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", ByteSizeKeys: []string{"_bytes"}}))
+
+	logger.Info("upload", "body_bytes", 2048)
+
+	expected := "\x1b[2m[]\x1b[0m \x1b[42m\x1b[30m INFO \x1b[0m upload \x1b[90mbody_bytes=\x1b[0m\x1b[36m2.0 KiB\x1b[0m \x1b[2m(2048)\x1b[0m\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
@@ -0,0 +1,39 @@
+package humanslog
+
+import (
+	"net/url"
+	"path/filepath"
+)
+
+// hyperlink wraps text in an OSC 8 hyperlink to target when Hyperlinks are enabled
+func (h *Handler) hyperlink(text []byte, target string) []byte {
+	if !h.opts.Hyperlinks || h.opts.NoColor || target == "" {
+		return text
+	}
+
+	b := make([]byte, 0, len(text)+len(target)+12)
+	b = append(b, "\x1b]8;;"...)
+	b = append(b, target...)
+	b = append(b, "\x1b\\"...)
+	b = append(b, text...)
+	return append(b, "\x1b]8;;\x1b\\"...)
+}
+
+// sourceLink returns the hyperlink target of the source location
+func (h *Handler) sourceLink(file string, line int) string {
+	if h.opts.SourceLinkTemplate != "" {
+		return editorCommand(h.opts.SourceLinkTemplate, file, line)
+	}
+
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(file)}).String()
+}
+
+// urlLink returns s when it's an absolute URL which can be opened from the terminal
+func urlLink(s string) string {
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return ""
+	}
+
+	return s
+}
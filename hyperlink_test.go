@@ -0,0 +1,74 @@
+package humanslog
+
+import (
+	"fmt"
+	"log/slog"
+	"runtime"
+	"testing"
+)
+
+func Test_Hyperlink(t *testing.T) {
+	testHyperlinkURL(t)
+	testHyperlinkSource(t)
+	testHyperlinkNoColor(t)
+	testVisibleLenHyperlink(t)
+}
+
+func testHyperlinkURL(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", Hyperlinks: true}))
+
+	logger.Info("msg", slog.String("url", "https://example.com/a"), slog.String("path", "/health"))
+
+	expected := "\x1b[2m[]\x1b[0m \x1b[42m\x1b[30m INFO \x1b[0m msg " +
+		"\x1b[90murl=\x1b[0m\x1b]8;;https://example.com/a\x1b\\\x1b[36mhttps://example.com/a\x1b[0m\x1b]8;;\x1b\\ " +
+		"\x1b[90mpath=\x1b[0m\x1b[36m/health\x1b[0m\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testHyperlinkSource(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{
+		HandlerOptions:     &slog.HandlerOptions{AddSource: true},
+		TimeFormat:         "[]",
+		Hyperlinks:         true,
+		SourceLinkTemplate: "vscode://file/%f:%l",
+	}))
+
+	_, file, line, _ := runtime.Caller(0)
+	logger.Info("msg")
+
+	expected := fmt.Sprintf("\x1b]8;;vscode://file/%[1]s:%[2]d\x1b\\\x1b[37m%[1]s:%[2]d\x1b[0m\x1b]8;;\x1b\\", file, line+1)
+
+	if got := string(w.WrittenData); len(got) < 11+len(expected) || got[11:11+len(expected)] != expected {
+		t.Errorf("\nExpected:\n%q\nwithin:\n%q", expected, got)
+	}
+}
+
+func testHyperlinkNoColor(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, Hyperlinks: true}))
+
+	logger.Info("msg", slog.String("url", "https://example.com"))
+
+	expected := "[]  INFO  msg url=https://example.com\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testVisibleLenHyperlink(t *testing.T) {
+	b := []byte("\x1b]8;;https://example.com\x1b\\\x1b[36mlink\x1b[0m\x1b]8;;\x1b\\")
+
+	if n := visibleLen(b); n != 4 {
+		t.Errorf("Expected visible length 4, got %d", n)
+	}
+
+	if s := string(stripANSI(b)); s != "link" {
+		t.Errorf("Expected %q, got %q", "link", s)
+	}
+}
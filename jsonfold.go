@@ -0,0 +1,161 @@
+package humanslog
+
+import (
+	"bytes"
+	"encoding/json"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// jsonFoldMarker surrounds placeholders of folded JSON values, it can't occur in valid JSON
+const jsonFoldMarker = 0
+
+// foldJSON indents s with nested objects and arrays replaced by {…} and [… N items] placeholders,
+// values at paths matching JSONExpandKeys are expanded. It reports false when s isn't larger than JSONFoldSize.
+func (h *Handler) foldJSON(s string, baseIndent int) ([]byte, bool) {
+	if h.opts.JSONFoldSize <= 0 || len(s) <= h.opts.JSONFoldSize {
+		return nil, false
+	}
+
+	b, err := h.appendFoldedJSON(nil, json.RawMessage(s), "", strings.Repeat(" ", baseIndent*2), true)
+	if err != nil {
+		return nil, false
+	}
+
+	return b, true
+}
+
+func (h *Handler) appendFoldedJSON(b []byte, raw json.RawMessage, p string, indent string, top bool) ([]byte, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || (raw[0] != '{' && raw[0] != '[') {
+		return append(b, raw...), nil
+	}
+
+	if !top && h.jsonPathExpanded(p) {
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, raw, indent, "  "); err != nil {
+			return nil, err
+		}
+		return append(b, indented.Bytes()...), nil
+	}
+
+	if raw[0] == '[' {
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return nil, err
+		}
+		if len(items) == 0 {
+			return append(b, "[]"...), nil
+		}
+		if !top && !h.jsonPathOnExpandedRoute(p) {
+			return appendJSONPlaceholder(b, h.glyphs("[… "+strconv.Itoa(len(items))+" items]")), nil
+		}
+
+		// Elements of arrays have the path of the array
+		b = append(b, "[\n"...)
+		for i, item := range items {
+			b = append(b, indent+"  "...)
+			var err error
+			if b, err = h.appendFoldedJSON(b, item, p, indent+"  ", false); err != nil {
+				return nil, err
+			}
+			if i < len(items)-1 {
+				b = append(b, ',')
+			}
+			b = append(b, '\n')
+		}
+		return append(b, indent+"]"...), nil
+	}
+
+	if !top && !h.jsonPathOnExpandedRoute(p) {
+		if bytes.Equal(bytes.Join(bytes.Fields(raw), nil), []byte("{}")) {
+			return append(b, "{}"...), nil
+		}
+		return appendJSONPlaceholder(b, h.glyphs("{…}")), nil
+	}
+
+	// Objects are decoded key by key to keep their order
+	d := json.NewDecoder(bytes.NewReader(raw))
+	if _, err := d.Token(); err != nil {
+		return nil, err
+	}
+	if !d.More() {
+		return append(b, "{}"...), nil
+	}
+
+	b = append(b, "{\n"...)
+	first := true
+	for d.More() {
+		t, err := d.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := t.(string)
+
+		var v json.RawMessage
+		if err := d.Decode(&v); err != nil {
+			return nil, err
+		}
+
+		if !first {
+			b = append(b, ",\n"...)
+		}
+		first = false
+
+		kb, _ := json.Marshal(key)
+		b = append(b, indent+"  "...)
+		b = append(b, kb...)
+		b = append(b, ": "...)
+
+		kp := key
+		if p != "" {
+			kp = p + "." + key
+		}
+		if b, err = h.appendFoldedJSON(b, v, kp, indent+"  ", false); err != nil {
+			return nil, err
+		}
+	}
+
+	return append(b, "\n"+indent+"}"...), nil
+}
+
+func appendJSONPlaceholder(b []byte, s string) []byte {
+	b = append(b, jsonFoldMarker)
+	b = append(b, s...)
+	return append(b, jsonFoldMarker)
+}
+
+// jsonPathExpanded reports whether the value at the dot-separated path p matches a pattern of JSONExpandKeys
+func (h *Handler) jsonPathExpanded(p string) bool {
+	for _, pattern := range h.opts.JSONExpandKeys {
+		if ok, _ := path.Match(jsonPathPattern(pattern), jsonPathPattern(p)); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// jsonPathOnExpandedRoute reports whether a pattern of JSONExpandKeys matches a value nested in the value at p
+func (h *Handler) jsonPathOnExpandedRoute(p string) bool {
+	segments := strings.Count(p, ".") + 1
+	for _, pattern := range h.opts.JSONExpandKeys {
+		parts := strings.Split(pattern, ".")
+		if len(parts) <= segments {
+			continue
+		}
+
+		prefix := strings.Join(parts[:segments], ".")
+		if ok, _ := path.Match(jsonPathPattern(prefix), jsonPathPattern(p)); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// jsonPathPattern uses / as the separator, so * in path.Match doesn't match across dots
+func jsonPathPattern(p string) string {
+	return strings.ReplaceAll(p, ".", "/")
+}
@@ -0,0 +1,81 @@
+package humanslog
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func Test_JSONFold(t *testing.T) {
+	testJSONFold(t)
+	testJSONFoldExpandKeys(t)
+	testJSONFoldSmall(t)
+}
+
+const foldedPayload = `{"id":1,"user":{"name":"gopher","roles":["admin"]},"items":[{"id":1,"name":"a"},{"id":2,"name":"b"}],"tags":[],"ok":true}`
+
+func testJSONFold(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, JSONFoldSize: 32}))
+
+	logger.Info("msg", slog.String("payload", foldedPayload))
+
+	expected := "[]  INFO  msgJ payload={\n" +
+		"  \"id\": 1,\n" +
+		"  \"user\": {…},\n" +
+		"  \"items\": [… 2 items],\n" +
+		"  \"tags\": [],\n" +
+		"  \"ok\": true\n" +
+		"}\n\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testJSONFoldExpandKeys(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, JSONFoldSize: 32, JSONExpandKeys: []string{"user.name", "items.id"}}))
+
+	logger.Info("msg", slog.String("payload", foldedPayload))
+
+	expected := "[]  INFO  msgJ payload={\n" +
+		"  \"id\": 1,\n" +
+		"  \"user\": {\n" +
+		"    \"name\": \"gopher\",\n" +
+		"    \"roles\": [… 1 items]\n" +
+		"  },\n" +
+		"  \"items\": [\n" +
+		"    {\n" +
+		"      \"id\": 1,\n" +
+		"      \"name\": \"a\"\n" +
+		"    },\n" +
+		"    {\n" +
+		"      \"id\": 2,\n" +
+		"      \"name\": \"b\"\n" +
+		"    }\n" +
+		"  ],\n" +
+		"  \"tags\": [],\n" +
+		"  \"ok\": true\n" +
+		"}\n\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testJSONFoldSmall(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, JSONFoldSize: 1024}))
+
+	logger.Info("msg", slog.String("payload", `{"user":{"name":"gopher"}}`))
+
+	expected := "[]  INFO  msgJ payload={\n" +
+		"  \"user\": {\n" +
+		"    \"name\": \"gopher\"\n" +
+		"  }\n" +
+		"}\n\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
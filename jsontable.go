@@ -0,0 +1,143 @@
+package humanslog
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// Min share of filled cells for an array of objects to be rendered as a table
+const jsonTableMinFill = 0.5
+
+// formatJSONTable renders a JSON array of objects with mostly uniform keys as an aligned table, keys as columns.
+// Returns false if the JSON doesn't have that shape.
+func (h *Handler) formatJSONTable(s string, l int) ([]byte, bool) {
+	if !h.opts.JSONTables {
+		return nil, false
+	}
+
+	d := json.NewDecoder(strings.NewReader(s))
+	d.UseNumber()
+
+	var rows []map[string]any
+	if err := d.Decode(&rows); err != nil || len(rows) == 0 {
+		return nil, false
+	}
+
+	seen := make(map[string]struct{})
+	var columns []string
+	cells := 0
+	for _, row := range rows {
+		if row == nil {
+			return nil, false
+		}
+
+		for k := range row {
+			if _, ok := seen[k]; !ok {
+				seen[k] = struct{}{}
+				columns = append(columns, k)
+			}
+		}
+
+		cells += len(row)
+	}
+
+	if len(columns) == 0 || float64(cells) < jsonTableMinFill*float64(len(rows)*len(columns)) {
+		return nil, false
+	}
+
+	sort.Strings(columns)
+
+	widths := make([]int, len(columns))
+	texts := make([][]string, len(rows))
+	for i, c := range columns {
+		widths[i] = displayWidth(c)
+	}
+
+	for r, row := range rows {
+		texts[r] = make([]string, len(columns))
+		for i, c := range columns {
+			v, ok := row[c]
+			if !ok {
+				continue
+			}
+
+			texts[r][i] = jsonCellText(v)
+			widths[i] = max(widths[i], displayWidth(texts[r][i]))
+		}
+	}
+
+	indent := bytes.Repeat([]byte(" "), l*2+4)
+
+	b := []byte{'\n'}
+	b = append(b, indent...)
+	for i, c := range columns {
+		b = h.appendTableCell(b, h.colorString([]byte(c), h.keyColor()), displayWidth(c), widths[i], i == len(columns)-1)
+	}
+
+	for r, row := range rows {
+		b = append(b, '\n')
+		b = append(b, indent...)
+		for i, c := range columns {
+			t := texts[r][i]
+			b = h.appendTableCell(b, h.jsonCellColor(row[c], t), displayWidth(t), widths[i], i == len(columns)-1)
+		}
+	}
+
+	return b, true
+}
+
+// appendTableCell appends a cell padded to width, followed by column separator
+func (h *Handler) appendTableCell(b []byte, cell []byte, cellWidth int, width int, last bool) []byte {
+	b = append(b, cell...)
+	if last {
+		return b
+	}
+
+	return append(b, bytes.Repeat([]byte(" "), width-cellWidth+2)...)
+}
+
+func jsonCellText(v any) string {
+	switch vv := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return vv
+	case json.Number:
+		return vv.String()
+	case bool:
+		if vv {
+			return "true"
+		}
+		return "false"
+	default:
+		b, err := json.Marshal(vv)
+		if err != nil {
+			return ""
+		}
+		return string(b)
+	}
+}
+
+// jsonCellColor colors the cell text the same way as colorized JSON values
+func (h *Handler) jsonCellColor(v any, t string) []byte {
+	switch vv := v.(type) {
+	case nil:
+		if t == "" {
+			return nil
+		}
+		return h.colorString([]byte(t), fgYellow)
+	case json.Number:
+		return h.colorString([]byte(t), h.numberColor())
+	case bool:
+		if vv {
+			return h.colorString([]byte(t), fgGreen)
+		}
+		return h.colorString([]byte(t), fgRed)
+	case string:
+		return h.colorString([]byte(t), h.stringColor(fgWhite))
+	default:
+		return h.formatJSONInline(t)
+	}
+}
@@ -0,0 +1,44 @@
+package humanslog
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+func Test_JSONTable(t *testing.T) {
+	testJSONTable(t)
+	testJSONTableNotUniform(t)
+}
+
+func testJSONTable(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, JSONTables: true}))
+
+	users := `[{"id":1,"name":"John","admin":true},{"id":22,"name":"Čeněk"},{"id":3,"name":null,"admin":false}]`
+
+	logger.Info("msg", slog.String("users", users))
+
+	expected := "[]  INFO  msgJ users=\n" +
+		"    admin  id  name\n" +
+		"    true   1   John\n" +
+		"           22  Čeněk\n" +
+		"    false  3   null\n\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testJSONTableNotUniform(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, JSONTables: true}))
+
+	logger.Info("msg", slog.String("v", `[{"a":1},{"b":2},{"c":3}]`))
+
+	expected := "[]  INFO  msgJ v=[\n  {\n    \"a\": 1\n  },\n  {\n    \"b\": 2\n  },\n  {\n    \"c\": 3\n  }\n]\n\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
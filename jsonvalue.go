@@ -0,0 +1,65 @@
+package humanslog
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+)
+
+// jsonText returns json.RawMessage, map[string]any and []any values as compact JSON with secrets masked under their
+// JSON keys below path, the key of the value
+func (h *Handler) jsonText(v any, path []string) (string, bool) {
+	s, ok := compactJSON(v)
+	if !ok {
+		return "", false
+	}
+
+	return h.maskJSONSecrets(h.redactString(s), path), true
+}
+
+// compactJSON returns json.RawMessage, map[string]any and []any values as compact JSON, it reports false for other values,
+// values which aren't valid JSON or can't be encoded and when a marshaler panics
+func compactJSON(v any) (s string, ok bool) {
+	defer func() {
+		if recover() != nil {
+			s, ok = "", false
+		}
+	}()
+
+	var buf bytes.Buffer
+	switch x := v.(type) {
+	case json.RawMessage:
+		if err := json.Compact(&buf, x); err != nil {
+			return "", false
+		}
+	case map[string]any, []any:
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(x); err != nil {
+			return "", false
+		}
+	default:
+		return "", false
+	}
+
+	return string(bytes.TrimSpace(buf.Bytes())), true
+}
+
+// isMultilineJSON reports whether the value is JSON-shaped data larger than JSONInlineSize, indented in the multiline section
+func (h *Handler) isMultilineJSON(v slog.Value) bool {
+	if v.Kind() != slog.KindAny {
+		return false
+	}
+
+	s, ok := compactJSON(v.Any())
+	if !ok {
+		return false
+	}
+
+	size := h.opts.JSONInlineSize
+	if size == 0 {
+		size = 80
+	}
+
+	return len(s) > size
+}
@@ -0,0 +1,101 @@
+package humanslog
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func Test_JSONValues(t *testing.T) {
+	testJSONValuesInline(t)
+	testJSONValuesMultiline(t)
+	testJSONValuesInvalid(t)
+	testJSONValuesRedacted(t)
+	testJSONValuesNestedSecret(t)
+}
+
+func testJSONValuesInline(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true}))
+
+	logger.Info("msg", "raw", json.RawMessage(`{"a": 1, "b": [1, 2]}`), "m", map[string]any{"x": "<y>", "n": nil}, "l", []any{1, "two"})
+
+	expected := `[]  INFO  msg raw={"a":1,"b":[1,2]} m={"n":null,"x":"<y>"} l=[1,"two"]` + "\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testJSONValuesMultiline(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, JSONInlineSize: 10}))
+
+	logger.Info("msg", "m", map[string]any{"items": []any{1, 2}, "name": "a"}, "k", 1)
+
+	expected := "[]  INFO  msg k=1J m={\n" +
+		"  \"items\": [\n" +
+		"    1,\n" +
+		"    2\n" +
+		"  ],\n" +
+		"  \"name\": \"a\"\n" +
+		"}\n\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testJSONValuesInvalid(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true}))
+
+	logger.Info("msg", "raw", json.RawMessage(`{`))
+
+	expected := "[]  INFO  msg raw={\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testJSONValuesRedacted(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, Redact: &RedactOptions{Keys: []string{"password"}}}))
+
+	logger.Info("msg", "m", map[string]any{"user": "bob", "password": "hunter2"})
+
+	expected := `[]  INFO  msg m={"password":"[REDACTED]","user":"bob"}` + "\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testJSONValuesNestedSecret(t *testing.T) {
+	w := &MockWriter{}
+
+	var masked []string
+	logger := slog.New(NewHandler(w, &Options{
+		TimeFormat:     "[]",
+		NoColor:        true,
+		MaskSecrets:    true,
+		JSONInlineSize: 200,
+		OnSecretMasked: func(key string, kind string) {
+			masked = append(masked, key+":"+kind)
+		},
+	}))
+
+	logger.Info("msg", "cfg", map[string]any{"api_token": "0123456789abcdef0123456789abcdef", "id": "0123456789abcdef0123456789abcdef"})
+
+	expected := `[]  INFO  msg cfg={"api_token":"[REDACTED hex-secret]","id":"0123456789abcdef0123456789abcdef"}` + "\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+
+	if len(masked) != 1 || masked[0] != "cfg.api_token:hex-secret" {
+		t.Errorf("Expected callback %q, got %q", "cfg.api_token:hex-secret", masked)
+	}
+}
@@ -0,0 +1,67 @@
+package humanslog
+
+import (
+	"log/slog"
+	"path"
+	"strings"
+)
+
+// filterKeys removes attributes matching HideKeys and, when OnlyKeys is set, attributes matching none of them.
+// Keys are matched after ReplaceAttr, the attributes are left for ReplaceAttr to be applied while rendering.
+func (h *Handler) filterKeys(as attributes, group []string) attributes {
+	if len(h.opts.HideKeys) == 0 && len(h.opts.OnlyKeys) == 0 {
+		return as
+	}
+
+	filtered := make(attributes, 0, len(as))
+	for _, a := range as {
+		key := a.Key
+		if h.opts.ReplaceAttr != nil && a.Value.Kind() != slog.KindGroup {
+			key = h.opts.ReplaceAttr(group, a).Key
+		}
+
+		if matchKey(h.opts.HideKeys, group, key) {
+			continue
+		}
+
+		if a.Value.Kind() == slog.KindGroup {
+			if len(h.opts.OnlyKeys) > 0 && matchKey(h.opts.OnlyKeys, group, key) {
+				// The whole group was asked for, only hidden keys are removed from it
+				only := *h
+				only.opts.OnlyKeys = nil
+				a.Value = slog.GroupValue(only.filterKeys(a.Value.Group(), append(group, key))...)
+			} else {
+				a.Value = slog.GroupValue(h.filterKeys(a.Value.Group(), append(group, key))...)
+			}
+
+			if len(a.Value.Group()) == 0 {
+				continue
+			}
+		} else if len(h.opts.OnlyKeys) > 0 && !matchKey(h.opts.OnlyKeys, group, key) {
+			continue
+		}
+
+		filtered = append(filtered, a)
+	}
+
+	return filtered
+}
+
+// matchKey reports whether the key or its dotted path matches any of the patterns
+func matchKey(patterns []string, group []string, key string) bool {
+	full := key
+	if len(group) > 0 {
+		full = strings.Join(group, ".") + "." + key
+	}
+
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, key); ok {
+			return true
+		}
+		if ok, _ := path.Match(p, full); ok {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,92 @@
+package humanslog
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+func Test_Keys(t *testing.T) {
+	testHideKeys(t)
+	testHideKeysInGroup(t)
+	testHideKeysAfterReplaceAttr(t)
+	testOnlyKeys(t)
+	testOnlyKeysGroup(t)
+}
+
+func testHideKeys(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, HideKeys: []string{"caller", "pay*"}}))
+
+	logger.Info("msg", "caller", "main.go:1", "payload", "...", "user", "ann")
+
+	expected := "[]  INFO  msg user=ann\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testHideKeysInGroup(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, Format: FormatOneLine, HideKeys: []string{"http.body", "secret"}}))
+
+	logger.Info("msg", slog.Group("http", "method", "GET", "body", "{}", "secret", "s"), slog.Group("empty", "secret", "s"))
+
+	expected := "[]  INFO  msg http.method=GET\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testHideKeysAfterReplaceAttr(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{
+		HandlerOptions: &slog.HandlerOptions{
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if a.Key == "src" {
+					a.Key = "caller"
+				}
+				return a
+			},
+		},
+		TimeFormat: "[]",
+		NoColor:    true,
+		HideKeys:   []string{"caller"},
+	}))
+
+	logger.Info("msg", "src", "main.go:1", "user", "ann")
+
+	expected := "[]  INFO  msg user=ann\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testOnlyKeys(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, Format: FormatOneLine, OnlyKeys: []string{"user", "http.status"}}))
+
+	logger.With("service", "api").Info("msg", "user", "ann", "payload", "...", slog.Group("http", "method", "GET", "status", 200))
+
+	expected := "[]  INFO  msg user=ann http.status=200\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testOnlyKeysGroup(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, Format: FormatOneLine, OnlyKeys: []string{"http"}, HideKeys: []string{"body"}}))
+
+	logger.Info("msg", "user", "ann", slog.Group("http", "method", "GET", "body", "{}"))
+
+	expected := "[]  INFO  msg http.method=GET\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
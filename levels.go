@@ -0,0 +1,48 @@
+package humanslog
+
+import (
+	"log/slog"
+)
+
+// LevelSpec is the name and color of a level registered with Options.CustomLevels, e.g. {Level: -8, Name: "TRACE", Color: Cyan}
+type LevelSpec struct {
+	Level slog.Level
+	Name  string
+	Color Color
+}
+
+// levelSpec returns the built-in or custom level closest to level from below, levels below all of them use the lowest one.
+// Custom levels replace built-in levels with the same value.
+func (h *Handler) levelSpec(level slog.Level) LevelSpec {
+	builtin := [...]LevelSpec{
+		{Level: slog.LevelDebug, Name: "DEBUG", Color: h.opts.DebugColor},
+		{Level: slog.LevelInfo, Name: "INFO", Color: h.opts.InfoColor},
+		{Level: slog.LevelWarn, Name: "WARN", Color: h.opts.WarnColor},
+		{Level: slog.LevelError, Name: "ERROR", Color: h.opts.ErrorColor},
+	}
+
+	lowest := builtin[0]
+	var below LevelSpec
+	hasBelow := false
+	pick := func(s LevelSpec) {
+		if s.Level <= lowest.Level {
+			lowest = s
+		}
+		if s.Level <= level && (!hasBelow || s.Level >= below.Level) {
+			below, hasBelow = s, true
+		}
+	}
+
+	for _, s := range builtin {
+		pick(s)
+	}
+	for _, s := range h.opts.CustomLevels {
+		pick(s)
+	}
+
+	if !hasBelow {
+		return lowest
+	}
+
+	return below
+}
@@ -0,0 +1,63 @@
+package humanslog
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+)
+
+func Test_CustomLevels(t *testing.T) {
+	testCustomLevels(t)
+	testCustomLevelOffsets(t)
+}
+
+func testCustomLevels(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{
+		HandlerOptions: &slog.HandlerOptions{Level: slog.Level(-8)},
+		TimeFormat:     "[]",
+		CustomLevels: []LevelSpec{
+			{Level: -8, Name: "TRACE", Color: Cyan},
+			{Level: 2, Name: "NOTICE", Color: Magenta},
+			{Level: 12, Name: "FATAL"},
+		},
+	}))
+
+	ctx := context.Background()
+	logger.Log(ctx, -8, "msg")
+	logger.Debug("msg")
+	logger.Log(ctx, 2, "msg")
+	logger.Log(ctx, 12, "msg")
+
+	expected := "\x1b[2m[]\x1b[0m \x1b[46m\x1b[30m TRACE \x1b[0m msg\n" +
+		"\x1b[2m[]\x1b[0m \x1b[44m\x1b[30m DEBUG \x1b[0m msg\n" +
+		"\x1b[2m[]\x1b[0m \x1b[45m\x1b[30m NOTICE \x1b[0m msg\n" +
+		"\x1b[2m[]\x1b[0m \x1b[41m\x1b[30m FATAL \x1b[0m msg\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testCustomLevelOffsets(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{
+		HandlerOptions: &slog.HandlerOptions{Level: slog.Level(-10)},
+		TimeFormat:     "[]",
+		NoColor:        true,
+		CustomLevels:   []LevelSpec{{Level: -8, Name: "TRACE"}, {Level: 12, Name: "FATAL"}},
+	}))
+
+	ctx := context.Background()
+	logger.Log(ctx, -10, "msg")
+	logger.Log(ctx, -6, "msg")
+	logger.Log(ctx, 13, "msg")
+
+	expected := "[]  TRACE-2  msg\n" +
+		"[]  TRACE+2  msg\n" +
+		"[]  FATAL+1  msg\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
@@ -0,0 +1,76 @@
+package humanslog
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// LevelVar returns the *slog.LevelVar set as HandlerOptions.Level, a fixed level is backed by an internal LevelVar.
+// It's nil for other slog.Leveler implementations. Changes of the LevelVar apply to the handler and all handlers
+// derived from it.
+func (h *Handler) LevelVar() *slog.LevelVar {
+	if h.level != nil {
+		return h.level
+	}
+
+	lv, _ := h.opts.Level.(*slog.LevelVar)
+	return lv
+}
+
+// leveler returns the minimum level of the handler, the internal LevelVar of a fixed level or HandlerOptions.Level
+func (h *Handler) leveler() slog.Leveler {
+	if h.level != nil {
+		return h.level
+	}
+
+	return h.opts.Level
+}
+
+// stepLevel moves the level by delta steps of 4 (Debug, Info, Warn, Error), staying within Debug and Error
+func stepLevel(lv *slog.LevelVar, delta int) {
+	l := lv.Level() + slog.Level(delta*4)
+	lv.Set(min(max(l, slog.LevelDebug), slog.LevelError))
+}
+
+// LevelHandler returns an HTTP handler reading and changing the level of lv:
+// GET returns the level, PUT or POST sets it from the "level" query parameter or the body (e.g. "debug" or "WARN+2").
+func LevelHandler(lv *slog.LevelVar) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut, http.MethodPost:
+			level := r.URL.Query().Get("level")
+			if level == "" {
+				var body strings.Builder
+				r.Body = http.MaxBytesReader(w, r.Body, 64)
+				if _, err := io.Copy(&body, r.Body); err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				level = strings.TrimSpace(body.String())
+			}
+
+			if err := lv.UnmarshalText([]byte(level)); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		default:
+			w.Header().Set("Allow", "GET, PUT, POST")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		_, _ = io.WriteString(w, lv.Level().String()+"\n")
+	})
+}
+
+// SetLevel changes the minimum level through LevelVar, it applies to all handlers sharing it: the handler it was
+// derived from and all handlers derived from either. It's safe to call while logging, other slog.Leveler
+// implementations aren't changed.
+func (h *Handler) SetLevel(l slog.Level) {
+	if lv := h.LevelVar(); lv != nil {
+		lv.Set(l)
+	}
+}
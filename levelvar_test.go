@@ -0,0 +1,146 @@
+package humanslog
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func Test_LevelVar(t *testing.T) {
+	testHandlerLevelVar(t)
+	testSetLevel(t)
+	testSetLevelShared(t)
+	testStepLevel(t)
+	testLevelHandler(t)
+}
+
+func testHandlerLevelVar(t *testing.T) {
+	lv := &slog.LevelVar{}
+	w := &MockWriter{}
+	h := NewHandler(w, &Options{HandlerOptions: &slog.HandlerOptions{Level: lv}, TimeFormat: "[]", NoColor: true})
+
+	if h.LevelVar() != lv {
+		t.Fatal("Expected the LevelVar of HandlerOptions")
+	}
+
+	logger := slog.New(h).With("a", 1)
+	logger.Debug("dropped")
+	h.LevelVar().Set(slog.LevelDebug)
+	logger.Debug("kept")
+
+	expected := "[]  DEBUG  kept a=1\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+
+	if lv := NewHandler(w, nil).LevelVar(); lv == nil || lv.Level() != slog.LevelInfo {
+		t.Errorf("Expected an Info LevelVar for a fixed level, got %v", lv)
+	}
+}
+
+func testSetLevel(t *testing.T) {
+	w := &MockWriter{}
+	h := NewHandler(w, &Options{TimeFormat: "[]", NoColor: true})
+	h.SetLevel(slog.LevelDebug)
+	slog.New(h).Debug("fixed")
+
+	lv := &slog.LevelVar{}
+	h2 := NewHandler(w, &Options{HandlerOptions: &slog.HandlerOptions{Level: lv}, TimeFormat: "[]", NoColor: true})
+	logger := slog.New(h2).With("a", 1)
+	h2.SetLevel(slog.LevelDebug)
+	logger.Debug("var")
+
+	expected := "[]  DEBUG  fixed\n[]  DEBUG  var a=1\n"
+
+	if string(w.WrittenData) != expected || lv.Level() != slog.LevelDebug {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testSetLevelShared(t *testing.T) {
+	ho := &slog.HandlerOptions{Level: slog.LevelInfo}
+	h := NewHandler(&MockWriter{}, &Options{HandlerOptions: ho})
+	child := h.WithAttrs([]slog.Attr{slog.Int("a", 1)}).(*Handler)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		logger := slog.New(h)
+		for i := 0; i < 100; i++ {
+			logger.With("i", i).WithGroup("g").Debug("msg")
+		}
+	}()
+
+	child.SetLevel(slog.LevelDebug)
+	wg.Wait()
+
+	if !child.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("Expected the child to be enabled for Debug")
+	}
+
+	if !h.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("Expected the parent to be enabled for Debug")
+	}
+
+	if ho.Level != slog.LevelInfo {
+		t.Errorf("Expected the caller's HandlerOptions to stay at Info, got %v", ho.Level)
+	}
+}
+
+func testStepLevel(t *testing.T) {
+	lv := &slog.LevelVar{}
+
+	stepLevel(lv, -1)
+	stepLevel(lv, -1)
+	if lv.Level() != slog.LevelDebug {
+		t.Errorf("Expected Debug, got %s", lv.Level())
+	}
+
+	stepLevel(lv, 5)
+	if lv.Level() != slog.LevelError {
+		t.Errorf("Expected Error, got %s", lv.Level())
+	}
+}
+
+func testLevelHandler(t *testing.T) {
+	lv := &slog.LevelVar{}
+	h := LevelHandler(lv)
+
+	tests := []struct {
+		method     string
+		target     string
+		body       string
+		code       int
+		response   string
+		finalLevel slog.Level
+	}{
+		{http.MethodGet, "/", "", http.StatusOK, "INFO\n", slog.LevelInfo},
+		{http.MethodPut, "/?level=debug", "", http.StatusOK, "DEBUG\n", slog.LevelDebug},
+		{http.MethodPost, "/", "warn\n", http.StatusOK, "WARN\n", slog.LevelWarn},
+		{http.MethodPost, "/", "loud", http.StatusBadRequest, "", slog.LevelWarn},
+		{http.MethodDelete, "/", "", http.StatusMethodNotAllowed, "", slog.LevelWarn},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body)))
+
+		if rec.Code != tt.code {
+			t.Errorf("%s %s: expected status %d, got %d", tt.method, tt.target, tt.code, rec.Code)
+		}
+
+		if tt.response != "" && rec.Body.String() != tt.response {
+			t.Errorf("%s %s: expected %q, got %q", tt.method, tt.target, tt.response, rec.Body.String())
+		}
+
+		if lv.Level() != tt.finalLevel {
+			t.Errorf("%s %s: expected level %s, got %s", tt.method, tt.target, tt.finalLevel, lv.Level())
+		}
+	}
+}
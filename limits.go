@@ -0,0 +1,44 @@
+package humanslog
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// enterValue reports whether a nested slice, map or struct is rendered within MaxDepth and descends into it,
+// leaveValue must be called when it's rendered
+func (h *Handler) enterValue(vi *visited) bool {
+	if h.opts.MaxDepth > 0 && vi.depth >= h.opts.MaxDepth {
+		return false
+	}
+
+	vi.depth++
+	return true
+}
+
+func (h *Handler) leaveValue(vi *visited) {
+	vi.depth--
+}
+
+// overBudget reports whether the value rendered so far reached MaxValueBytes
+func (h *Handler) overBudget(vi *visited) bool {
+	return h.opts.MaxValueBytes > 0 && vi.size >= h.opts.MaxValueBytes
+}
+
+// appendElement appends the rendered element and accounts its visible bytes
+func (h *Handler) appendElement(b []byte, t reflect.Type, v reflect.Value, l int, p int, vi *visited) []byte {
+	start := vi.size
+	e := h.elementType(t, v, l, p, vi)
+	vi.size = start + visibleLen(e)
+
+	return append(b, e...)
+}
+
+// appendCutOff appends the ellipsis of a value cut off by MaxDepth, or by MaxValueBytes with the number of elements left
+func (h *Handler) appendCutOff(b []byte, left int) []byte {
+	if left <= 0 {
+		return append(b, h.colorString([]byte(h.glyphs("…")), fgCyan)...)
+	}
+
+	return append(b, h.faintedText([]byte(h.glyphs("… +"+strconv.Itoa(left)+" more")))...)
+}
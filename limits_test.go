@@ -0,0 +1,82 @@
+package humanslog
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+func Test_Limits(t *testing.T) {
+	testMaxDepth(t)
+	testMaxDepthInline(t)
+	testMaxValueBytes(t)
+	testMaxValueBytesPerAttribute(t)
+}
+
+type limitsNode struct {
+	Name string
+	Kids []limitsNode
+}
+
+var limitsTree = limitsNode{Name: "a", Kids: []limitsNode{{Name: "b", Kids: []limitsNode{{Name: "c"}}}}}
+
+func testMaxDepth(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, MaxDepth: 2}))
+
+	logger.Info("msg", slog.Any("tree", limitsTree))
+
+	expected := "[]  INFO  msgS tree=humanslog.limitsNode\n" +
+		"    Name: a\n" +
+		"    Kids: 1 []humanslog.limitsNode{humanslog.limitsNode{…}}\n" +
+		"\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testMaxDepthInline(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, MaxDepth: 1}))
+
+	logger.Info("msg", slog.Any("m", map[string][]int{"x": {1, 2}}))
+
+	expected := "[]  INFO  msg m=1 map[string][]int{x=2 []int{…}}\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testMaxValueBytes(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, MaxValueBytes: 30}))
+
+	logger.Info("msg", slog.Any("s", []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17}))
+
+	expected := "[]  INFO  msg s=17 []int{1 2 3 4 5 6 7 8 9 10 … +7 more}\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testMaxValueBytesPerAttribute(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, MaxValueBytes: 40}))
+
+	logger.Info("msg", slog.Any("a", limitsNode{Name: "a"}), slog.Any("b", limitsNode{Name: "b"}))
+
+	expected := "[]  INFO  msgS a=humanslog.limitsNode\n" +
+		"    Name: a\n" +
+		"    Kids: 0 []humanslog.limitsNode{}\n" +
+		"S b=humanslog.limitsNode\n" +
+		"    Name: b\n" +
+		"    Kids: 0 []humanslog.limitsNode{}\n" +
+		"\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
@@ -0,0 +1,207 @@
+package humanslog
+
+import (
+	"context"
+	"encoding"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Time format of FormatLogfmt, the same as slog.TextHandler
+const logfmtTimeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// formatRecord renders r in the layout of Options.Format, decorated with LinePrefix and LineSuffix
+func (h *Handler) formatRecord(ctx context.Context, b []byte, r *slog.Record) []byte {
+	start := len(b)
+	switch {
+	case h.opts.Format == FormatLogfmt:
+		b = h.formatLogfmtRecord(ctx, b, r)
+	case isBannerRecord(*r):
+		b = h.formatBanner(b, r)
+	default:
+		b = h.formatOneLine(ctx, b, r)
+	}
+
+	return h.decorateLine(b, start, r)
+}
+
+// formatLogfmtRecord renders r as strict logfmt without colors, groups are flattened with dot notation
+func (h *Handler) formatLogfmtRecord(ctx context.Context, b []byte, r *slog.Record) []byte {
+	start := len(b)
+
+	if !r.Time.IsZero() {
+		b = h.appendLogfmtBuiltin(b, start, slog.Time(slog.TimeKey, r.Time))
+	}
+
+	if a, ok := h.replaceBuiltin(slog.Any(slog.LevelKey, r.Level)); ok {
+		b = appendLogfmtKey(b, start, a.Key)
+		b = appendLogfmtString(b, h.levelString(r.Level, a.Value))
+	}
+
+	if h.opts.AddSource {
+		f, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		if f.File != "" {
+			b = h.appendLogfmtBuiltin(b, start, slog.String(slog.SourceKey, f.File+":"+strconv.Itoa(f.Line)))
+		}
+	}
+
+	b = h.appendLogfmtBuiltin(b, start, slog.String(slog.MessageKey, r.Message))
+
+	as := make(attributes, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		if _, ok := styleOf(a); !ok && !isProgress(a) && !isBanner(a) {
+			as = as.appendResolved(a)
+		}
+		return true
+	})
+	as = h.withHandlerAttrs(as)
+	for _, a := range contextAttrs(ctx) {
+		as = as.appendResolved(a)
+	}
+	as = h.filterKeys(as, nil)
+	as = resolveValuers(as)
+	as = h.maskSecrets(as, nil)
+	as = h.redactAttrs(as)
+	as = h.truncateStrings(as)
+	as = h.sortAttrs(as)
+
+	b = h.appendLogfmtAttrs(b, start, as, nil)
+
+	return append(b, '\n')
+}
+
+// replaceBuiltin applies ReplaceAttr to a built-in attribute, it reports false when the attribute is removed
+func (h *Handler) replaceBuiltin(a slog.Attr) (slog.Attr, bool) {
+	if h.opts.ReplaceAttr != nil {
+		a = h.opts.ReplaceAttr(nil, a)
+	}
+
+	return a, a.Key != ""
+}
+
+func (h *Handler) appendLogfmtBuiltin(b []byte, start int, a slog.Attr) []byte {
+	a, ok := h.replaceBuiltin(a)
+	if !ok {
+		return b
+	}
+
+	b = appendLogfmtKey(b, start, a.Key)
+	return h.appendLogfmtPairValue(b, a.Value, []string{a.Key})
+}
+
+func (h *Handler) appendLogfmtAttrs(b []byte, start int, as []slog.Attr, group []string) []byte {
+	for _, a := range as {
+		if a.Value.Kind() != slog.KindGroup && h.opts.ReplaceAttr != nil {
+			a = h.opts.ReplaceAttr(group, a)
+			a.Value = a.Value.Resolve()
+		}
+
+		if a.Value.Kind() == slog.KindGroup {
+			// Groups with an empty key are inlined, empty groups are omitted
+			g := group
+			if a.Key != "" {
+				g = append(g[:len(g):len(g)], a.Key)
+			}
+			b = h.appendLogfmtAttrs(b, start, a.Value.Group(), g)
+			continue
+		}
+
+		if a.Key == "" {
+			continue
+		}
+
+		key := a.Key
+		for i := len(group) - 1; i >= 0; i-- {
+			key = group[i] + "." + key
+		}
+
+		b = appendLogfmtKey(b, start, key)
+		b = h.appendLogfmtPairValue(b, a.Value, append(group[:len(group):len(group)], a.Key))
+	}
+
+	return b
+}
+
+// appendLogfmtKey appends key= with a separating space unless it's the first pair of the record starting at start
+func appendLogfmtKey(b []byte, start int, key string) []byte {
+	if len(b) > start {
+		b = append(b, ' ')
+	}
+
+	b = appendLogfmtString(b, key)
+	return append(b, '=')
+}
+
+func (h *Handler) appendLogfmtPairValue(b []byte, v slog.Value, path []string) []byte {
+	switch v.Kind() {
+	case slog.KindString:
+		return appendLogfmtString(b, v.String())
+	case slog.KindTime:
+		return appendLogfmtString(b, v.Time().Format(logfmtTimeFormat))
+	case slog.KindAny:
+		if js, ok := h.jsonText(v.Any(), path); ok {
+			return appendLogfmtString(b, js)
+		}
+		return appendLogfmtString(b, h.maskNestedSecrets(string(h.logfmtAnyText(v.Any())), path))
+	default:
+		return appendValue(b, v)
+	}
+}
+
+// logfmtAnyText returns the text of values like slog.TextHandler, a panic is rendered instead of crashing the application
+func (h *Handler) logfmtAnyText(v any) (b []byte) {
+	defer h.recoverFormatter(&b)
+
+	switch x := v.(type) {
+	case error:
+		return []byte(x.Error())
+	case textAppender:
+		t, err := x.AppendText(nil)
+		if err != nil {
+			return []byte("!ERROR:" + err.Error())
+		}
+		return t
+	case encoding.TextMarshaler:
+		t, err := x.MarshalText()
+		if err != nil {
+			return []byte("!ERROR:" + err.Error())
+		}
+		return t
+	case []byte:
+		return x
+	case hexDump:
+		return []byte(hex.EncodeToString(x))
+	case sqlNull:
+		return []byte("NULL")
+	default:
+		return fmt.Appendf(nil, "%+v", x)
+	}
+}
+
+// appendLogfmtString appends s, quoted when it's empty or contains spaces, quotes, = or unprintable characters
+func appendLogfmtString(b []byte, s string) []byte {
+	if logfmtNeedsQuoting(s) {
+		return strconv.AppendQuote(b, s)
+	}
+
+	return append(b, s...)
+}
+
+func logfmtNeedsQuoting(s string) bool {
+	if s == "" {
+		return true
+	}
+
+	for _, r := range s {
+		if r == utf8.RuneError || r == '"' || r == '=' || unicode.IsSpace(r) || !unicode.IsPrint(r) {
+			return true
+		}
+	}
+
+	return false
+}
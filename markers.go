@@ -0,0 +1,72 @@
+package humanslog
+
+// Markers are the characters before keys in the multiline section showing the kind of the value,
+// empty fields keep the default and a space blanks the marker
+type Markers struct {
+	// Numbers, default: #
+	Number string
+
+	// Booleans, default: #
+	Bool string
+
+	// Times and durations, default: @
+	Time string
+
+	// Errors, default: E
+	Error string
+
+	// JSON strings and values, default: J
+	JSON string
+
+	// SQL queries, default: Q
+	SQL string
+
+	// XML and HTML strings, default: X
+	XML string
+
+	// YAML strings, default: Y
+	YAML string
+
+	// URLs, default: *
+	URL string
+
+	// Groups, default: G
+	Group string
+
+	// HTTP requests and responses, default: H
+	HTTP string
+
+	// Values of Diff, default: D
+	Diff string
+
+	// Hex dumps, default: B
+	Bytes string
+
+	// Arrays, default: A
+	Array string
+
+	// Slices, default: S
+	Slice string
+
+	// Maps, default: M
+	Map string
+
+	// Structs, default: S
+	Struct string
+
+	// Nil values and values of unknown types, default: !
+	Nil string
+}
+
+// marker returns the marker of a value, custom of Markers or def, empty with NoMarkers
+func (h *Handler) marker(custom string, def string, c foregroundColor) []byte {
+	if h.opts.NoMarkers {
+		return []byte{}
+	}
+
+	if custom == "" {
+		custom = def
+	}
+
+	return h.colorString([]byte(h.glyphs(custom)), c)
+}
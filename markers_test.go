@@ -0,0 +1,55 @@
+package humanslog
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func Test_Markers(t *testing.T) {
+	testMarkersCustom(t)
+	testNoMarkers(t)
+	testMarkersASCII(t)
+}
+
+type markersTestStruct struct {
+	A int
+}
+
+func testMarkersCustom(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, Format: FormatExpanded, Markers: Markers{Struct: "$", Number: " "}}))
+
+	logger.Info("msg", "s", markersTestStruct{A: 1}, "n", 1, "ok", true)
+
+	expected := "[]  INFO  msg\n$ s =humanslog.markersTestStruct\n    A: 1\n  n =1\n# ok=true\n\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testNoMarkers(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, NoMarkers: true, Format: FormatExpanded}))
+
+	logger.Info("msg", "s", markersTestStruct{A: 1}, "n", 1)
+
+	expected := "[]  INFO  msg\n  s=humanslog.markersTestStruct\n    A: 1\n  n=1\n\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testMarkersASCII(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, ASCIIOnly: true, Markers: Markers{Struct: "•"}}))
+
+	logger.Info("msg", "s", markersTestStruct{A: 1})
+
+	expected := "[]  INFO  msg* s=humanslog.markersTestStruct\n    A: 1\n\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
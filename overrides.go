@@ -0,0 +1,63 @@
+package humanslog
+
+import "log/slog"
+
+// levelOverrideKey returns the attribute key LevelOverrides are matched against
+func (h *Handler) levelOverrideKey() string {
+	if h.opts.LevelOverrideKey != "" {
+		return h.opts.LevelOverrideKey
+	}
+
+	return "component"
+}
+
+// levelOverride returns the level of LevelOverrides for the value of the top-level override key,
+// set by WithAttrs or in the record, the record value wins
+func (h *Handler) levelOverride(r *slog.Record) (slog.Level, bool) {
+	key := h.levelOverrideKey()
+
+	var value string
+	found := false
+	grouped := false
+	for _, goa := range h.goas {
+		if goa.group != "" {
+			grouped = true
+			break
+		}
+
+		for _, a := range goa.attrs {
+			if a.Key == key {
+				value, found = a.Value.String(), true
+			}
+		}
+	}
+
+	if !grouped {
+		r.Attrs(func(a slog.Attr) bool {
+			if a.Key == key {
+				value, found = a.Value.Resolve().String(), true
+			}
+			return true
+		})
+	}
+
+	if !found {
+		return 0, false
+	}
+
+	leveler, ok := h.opts.LevelOverrides[value]
+	if !ok || leveler == nil {
+		return 0, false
+	}
+
+	return leveler.Level(), true
+}
+
+// recordLevel returns the minimum level of the record from LevelOverrides, PackageLevels or Level
+func (h *Handler) recordLevel(r *slog.Record) slog.Level {
+	if l, ok := h.levelOverride(r); ok {
+		return l
+	}
+
+	return h.packageLevel(r)
+}
@@ -0,0 +1,84 @@
+package humanslog
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+)
+
+func Test_LevelOverrides(t *testing.T) {
+	testLevelOverrides(t)
+	testLevelOverridesKey(t)
+	testLevelOverridesLevelVar(t)
+}
+
+func testLevelOverrides(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{
+		TimeFormat:     "[]",
+		NoColor:        true,
+		LevelOverrides: map[string]slog.Leveler{"db": slog.LevelDebug, "http": slog.LevelWarn},
+	}))
+
+	logger.With("component", "db").Debug("query")
+	logger.Debug("dropped")
+	logger.Debug("conn", "component", "db")
+	logger.With("component", "http").Info("dropped")
+	logger.With("component", "db").Debug("grouped", "component", "http")
+	logger.With("component", "db").WithGroup("g").Debug("in group")
+
+	expected := "[]  DEBUG  query component=db\n" +
+		"[]  DEBUG  conn component=db\n" +
+		"[]  DEBUG  in group component=db\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testLevelOverridesKey(t *testing.T) {
+	w := &MockWriter{}
+	h := NewHandler(w, &Options{
+		TimeFormat:       "[]",
+		NoColor:          true,
+		LevelOverrides:   map[string]slog.Leveler{"worker": slog.LevelDebug},
+		LevelOverrideKey: "logger",
+	})
+
+	if !h.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("Expected Debug to be enabled by an override")
+	}
+
+	logger := slog.New(h)
+	logger.Debug("kept", "logger", "worker")
+	logger.Debug("dropped", "component", "worker")
+
+	expected := "[]  DEBUG  kept logger=worker\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testLevelOverridesLevelVar(t *testing.T) {
+	w := &MockWriter{}
+	lv := &slog.LevelVar{}
+	lv.Set(slog.LevelError)
+
+	logger := slog.New(NewHandler(w, &Options{
+		TimeFormat:     "[]",
+		NoColor:        true,
+		LevelOverrides: map[string]slog.Leveler{"db": lv},
+	})).With("component", "db")
+
+	logger.Info("dropped")
+	lv.Set(slog.LevelDebug)
+	logger.Debug("kept")
+
+	expected := "[]  DEBUG  kept component=db\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
@@ -0,0 +1,55 @@
+package humanslog
+
+import (
+	"log/slog"
+	"runtime"
+	"strings"
+)
+
+// minLevel returns the lowest level any record can be logged at, including PackageLevels and LevelOverrides
+func (h *Handler) minLevel() slog.Level {
+	l := h.leveler().Level()
+	for _, pl := range h.opts.PackageLevels {
+		l = min(l, pl)
+	}
+
+	for _, ol := range h.opts.LevelOverrides {
+		if ol != nil {
+			l = min(l, ol.Level())
+		}
+	}
+
+	return l
+}
+
+// packageLevel returns the level for the package that logged the record, longest matching prefix wins
+func (h *Handler) packageLevel(r *slog.Record) slog.Level {
+	l := h.leveler().Level()
+	if len(h.opts.PackageLevels) == 0 || r.PC == 0 {
+		return l
+	}
+
+	f, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+	pkg := funcPackage(f.Function)
+
+	matched := -1
+	for prefix, pl := range h.opts.PackageLevels {
+		if len(prefix) > matched && (pkg == prefix || strings.HasPrefix(pkg, strings.TrimSuffix(prefix, "/")+"/")) {
+			l = pl
+			matched = len(prefix)
+		}
+	}
+
+	return l
+}
+
+// funcPackage returns the package path of a fully qualified function name,
+// dots in the last path element are escaped as %2e by the linker
+func funcPackage(function string) string {
+	slash := strings.LastIndexByte(function, '/')
+	if dot := strings.IndexByte(function[slash+1:], '.'); dot >= 0 {
+		function = function[:slash+1+dot]
+	}
+
+	return strings.ReplaceAll(function, "%2e", ".")
+}
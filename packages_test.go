@@ -0,0 +1,60 @@
+package humanslog
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+)
+
+func Test_PackageLevels(t *testing.T) {
+	testPackageLevels(t)
+	testPackageLevelsEnabled(t)
+	testFuncPackage(t)
+}
+
+func testPackageLevels(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{
+		HandlerOptions: &slog.HandlerOptions{Level: slog.LevelDebug},
+		TimeFormat:     "[]",
+		NoColor:        true,
+		PackageLevels: map[string]slog.Level{
+			"github.com/ThreeDotsLabs":           slog.LevelDebug,
+			"github.com/ThreeDotsLabs/humanslog": slog.LevelWarn,
+		},
+	}))
+
+	logger.Info("dropped")
+	logger.Warn("kept")
+
+	expected := "[]  WARN  kept\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testPackageLevelsEnabled(t *testing.T) {
+	h := NewHandler(&MockWriter{}, &Options{PackageLevels: map[string]slog.Level{"example.com/app": slog.LevelDebug}})
+
+	if !h.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("Expected Debug to be enabled when a package allows it")
+	}
+}
+
+func testFuncPackage(t *testing.T) {
+	tests := map[string]string{
+		"main.main":                            "main",
+		"github.com/a/b.(*T).Method":           "github.com/a/b",
+		"github.com/a/b.Func.func1":            "github.com/a/b",
+		"gopkg.in/yaml.v3.Unmarshal":           "gopkg.in/yaml",
+		"github.com/ThreeDotsLabs/humanslog.x": "github.com/ThreeDotsLabs/humanslog",
+	}
+
+	for function, expected := range tests {
+		if got := funcPackage(function); got != expected {
+			t.Errorf("funcPackage(%q): expected %q, got %q", function, expected, got)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package humanslog
+
+import (
+	"encoding"
+	"fmt"
+)
+
+// recoverFormatter replaces *b with "!PANIC in formatter: …" when a method called while formatting a value
+// (String, MarshalText, Error, ...) panicked, mirroring how slog renders panics in LogValue
+func (h *Handler) recoverFormatter(b *[]byte) {
+	if p := recover(); p != nil {
+		*b = h.colorString(fmt.Appendf(nil, "!PANIC in formatter: %v", p), fgRed)
+	}
+}
+
+// stringerText calls String of s, a panic is rendered instead of crashing the application
+func (h *Handler) stringerText(s fmt.Stringer) (b []byte) {
+	defer h.recoverFormatter(&b)
+
+	return []byte(s.String())
+}
+
+// errorText calls Error of err, a panic is rendered instead of crashing the application
+func (h *Handler) errorText(err error) (b []byte) {
+	defer h.recoverFormatter(&b)
+
+	return []byte(err.Error())
+}
+
+// marshalText calls MarshalText of m, errors and panics are rendered instead of the text
+func (h *Handler) marshalText(m encoding.TextMarshaler) (b []byte) {
+	defer h.recoverFormatter(&b)
+
+	t, err := m.MarshalText()
+	if err != nil {
+		return []byte("!ERROR:" + err.Error())
+	}
+
+	return t
+}
@@ -0,0 +1,63 @@
+package humanslog
+
+import (
+	"log/slog"
+	"testing"
+)
+
+type panickingStringer int
+
+func (panickingStringer) String() string {
+	panic("broken stringer")
+}
+
+type panickingError string
+
+func (panickingError) Error() string {
+	panic("broken error")
+}
+
+func Test_Panics(t *testing.T) {
+	testPanickingStringer(t)
+	testPanickingError(t)
+	testPanickingNestedValue(t)
+}
+
+func testPanickingStringer(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, StringerFormatter: true}))
+
+	logger.Info("msg", slog.Any("s", panickingStringer(0)))
+
+	expected := "[]  INFO  msg s=!PANIC in formatter: broken stringer\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testPanickingError(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true}))
+
+	logger.Error("msg", slog.Any("err", panickingError("")))
+
+	expected := "[]  ERROR  msg err=!PANIC in formatter: broken error\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testPanickingNestedValue(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, StringerFormatter: true}))
+
+	logger.Info("msg", slog.Any("m", map[string]panickingStringer{"a": 0}))
+
+	expected := "[]  INFO  msg m=1 map[string]humanslog.panickingStringer{a=!PANIC in formatter: broken stringer}\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
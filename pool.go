@@ -0,0 +1,26 @@
+package humanslog
+
+import "sync"
+
+// maxPooledBuffer is the capacity above which buffers aren't returned to the pool, so one huge record doesn't pin memory
+const maxPooledBuffer = 64 << 10
+
+var bufPool = sync.Pool{
+	New: func() any {
+		b := make([]byte, 0, 1024)
+		return &b
+	},
+}
+
+func getBuffer() *[]byte {
+	return bufPool.Get().(*[]byte)
+}
+
+func putBuffer(b *[]byte) {
+	if cap(*b) > maxPooledBuffer {
+		return
+	}
+
+	*b = (*b)[:0]
+	bufPool.Put(b)
+}
@@ -0,0 +1,69 @@
+package humanslog
+
+import (
+	"bytes"
+	"log/slog"
+	"strconv"
+	"sync"
+)
+
+// progressKey is the key of the attribute returned by Progress
+const progressKey = "humanslog.progress"
+
+type progressMarker struct{}
+
+// Progress returns an attribute marking its record as a progress update, on a terminal it overwrites
+// the previous progress record instead of adding a line, e.g. logger.Info("downloading", humanslog.Progress(), "done", "40%").
+// Other records end the progress display, the last progress record is left above them.
+func Progress() slog.Attr {
+	return slog.Any(progressKey, progressMarker{})
+}
+
+func isProgress(a slog.Attr) bool {
+	if a.Key != progressKey || a.Value.Kind() != slog.KindAny {
+		return false
+	}
+
+	_, ok := a.Value.Any().(progressMarker)
+	return ok
+}
+
+func isProgressRecord(r slog.Record) bool {
+	found := false
+	r.Attrs(func(a slog.Attr) bool {
+		found = isProgress(a)
+		return !found
+	})
+
+	return found
+}
+
+// progressDisplay tracks the progress record at the end of the output, it's shared by handlers derived with With and WithGroup
+type progressDisplay struct {
+	mu    sync.Mutex
+	lines int
+
+	// progress records are rewritten in place only on a terminal, set again by SetOutput
+	terminal bool
+}
+
+// frame prepends b with moving the cursor over the previous progress record when b replaces it, p.mu must be held
+func (p *progressDisplay) frame(b []byte, progress bool) []byte {
+	lines := p.lines
+	p.lines = 0
+	if !progress || !p.terminal {
+		return b
+	}
+
+	p.lines = bytes.Count(b, []byte("\n"))
+	if lines == 0 {
+		return b
+	}
+
+	f := make([]byte, 0, len(b)+16)
+	f = append(f, "\x1b["...)
+	f = strconv.AppendInt(f, int64(lines), 10)
+	f = append(f, 'A')
+	f = append(f, "\r\x1b[J"...)
+	return append(f, b...)
+}
@@ -0,0 +1,47 @@
+package humanslog
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+func Test_Progress(t *testing.T) {
+	testProgressInPlace(t)
+	testProgressNotTerminal(t)
+}
+
+func testProgressInPlace(t *testing.T) {
+	w := &MockWriter{}
+	h := NewHandler(w, &Options{TimeFormat: "[]", NoColor: true})
+	h.progress = &progressDisplay{terminal: true}
+	logger := slog.New(h)
+
+	logger.Info("downloading", Progress(), "done", "10%")
+	logger.With("file", "a.zip").Info("downloading", Progress(), "done", "50%")
+	logger.Info("downloaded")
+	logger.Info("unpacking", Progress(), "done", "0%")
+
+	expected := "[]  INFO  downloading done=10%\n" +
+		"\x1b[1A\r\x1b[J[]  INFO  downloading done=50% file=a.zip\n" +
+		"[]  INFO  downloaded\n" +
+		"[]  INFO  unpacking done=0%\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testProgressNotTerminal(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true}))
+
+	logger.Info("downloading", Progress(), "done", "10%")
+	logger.Info("downloading", Progress(), "done", "50%")
+
+	expected := "[]  INFO  downloading done=10%\n[]  INFO  downloading done=50%\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
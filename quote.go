@@ -0,0 +1,39 @@
+package humanslog
+
+import (
+	"strconv"
+	"strings"
+)
+
+// QuoteMode selects which inline values are quoted
+type QuoteMode int
+
+const (
+	// QuoteNever renders values as they are
+	QuoteNever QuoteMode = iota
+
+	// QuoteAuto quotes and escapes values which are empty or contain spaces, "=", quotes or control characters
+	QuoteAuto
+
+	// QuoteAlways quotes and escapes all values
+	QuoteAlways
+)
+
+// appendQuoted appends s quoted according to QuoteValues
+func (h *Handler) appendQuoted(b []byte, s string) []byte {
+	switch {
+	case h.opts.QuoteValues == QuoteAlways:
+		return strconv.AppendQuote(b, s)
+	case h.opts.QuoteValues == QuoteAuto && logfmtNeedsQuoting(s):
+		return strconv.AppendQuote(b, s)
+	}
+
+	return append(b, s...)
+}
+
+// formatErrorQuoted renders the error like formatError with the message quoted according to QuoteValues
+func (h *Handler) formatErrorQuoted(err error) (b []byte) {
+	defer h.recoverFormatter(&b)
+
+	return h.colorString(h.appendQuoted(nil, strings.Join(errorMessages(err), ": ")), fgRed)
+}
@@ -0,0 +1,64 @@
+package humanslog
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+func Test_QuoteValues(t *testing.T) {
+	testQuoteAuto(t)
+	testQuoteAlways(t)
+	testQuoteNever(t)
+	testQuoteKeepsColors(t)
+}
+
+func testQuoteAuto(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, QuoteValues: QuoteAuto}))
+
+	logger.Info("msg", "s", "ba na na", "empty", "", "plain", "banana", "ctl", "a\tb")
+
+	expected := "[]  INFO  msg s=\"ba na na\" empty=\"\" plain=banana ctl=\"a\\tb\"\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testQuoteAlways(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, QuoteValues: QuoteAlways}))
+
+	logger.Info("msg", "plain", "banana", "n", 1)
+
+	expected := "[]  INFO  msg plain=\"banana\" n=1\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testQuoteNever(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true}))
+
+	logger.Info("msg", "s", "ba na na", "empty", "")
+
+	expected := "[]  INFO  msg s=ba na na empty=\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testQuoteKeepsColors(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", QuoteValues: QuoteAuto}))
+
+	logger.Info("msg", "s", "a b")
+
+	if !bytes.Contains(w.WrittenData, []byte("\"a b\"")) || !bytes.Contains(w.WrittenData, []byte("\x1b[")) {
+		t.Errorf("expected colored quoted value, got %q", w.WrittenData)
+	}
+}
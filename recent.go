@@ -0,0 +1,69 @@
+package humanslog
+
+import (
+	"io"
+	"strings"
+	"sync"
+)
+
+// recentRecords is a ring buffer of the last formatted records, without colors
+type recentRecords struct {
+	mu      sync.Mutex
+	records []string
+	next    int
+	full    bool
+}
+
+func newRecentRecords(n int) *recentRecords {
+	return &recentRecords{records: make([]string, n)}
+}
+
+func (rr *recentRecords) add(b []byte) {
+	s := string(stripANSI(b))
+
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	rr.records[rr.next] = s
+	rr.next = (rr.next + 1) % len(rr.records)
+	if rr.next == 0 {
+		rr.full = true
+	}
+}
+
+func (rr *recentRecords) list() []string {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	if !rr.full {
+		return append([]string(nil), rr.records[:rr.next]...)
+	}
+
+	out := make([]string, 0, len(rr.records))
+	out = append(out, rr.records[rr.next:]...)
+	return append(out, rr.records[:rr.next]...)
+}
+
+// Recent returns the last RecentRecords formatted records without colors, oldest first
+func (h *Handler) Recent() []string {
+	if h.recent == nil {
+		return nil
+	}
+
+	return h.recent.list()
+}
+
+// DumpRecentOnPanic writes recent records to w when the goroutine panics and re-panics,
+// it must be deferred directly: defer h.DumpRecentOnPanic(os.Stderr)
+func (h *Handler) DumpRecentOnPanic(w io.Writer) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	if recent := h.Recent(); len(recent) > 0 {
+		_, _ = io.WriteString(w, "recent log records:\n"+strings.Join(recent, ""))
+	}
+
+	panic(r)
+}
@@ -0,0 +1,61 @@
+package humanslog
+
+import (
+	"bytes"
+	"log/slog"
+	"reflect"
+	"testing"
+)
+
+func Test_Recent(t *testing.T) {
+	testRecent(t)
+	testRecentDisabled(t)
+	testDumpRecentOnPanic(t)
+}
+
+func testRecent(t *testing.T) {
+	h := NewHandler(&MockWriter{}, &Options{TimeFormat: "[]", RecentRecords: 2})
+	logger := slog.New(h)
+
+	logger.Info("first")
+	logger.With("a", 1).Info("second")
+	logger.Warn("third")
+
+	expected := []string{"[]  INFO  second a=1\n", "[]  WARN  third\n"}
+
+	if got := h.Recent(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("\nExpected:\n%q\nGot:\n%q", expected, got)
+	}
+}
+
+func testRecentDisabled(t *testing.T) {
+	h := NewHandler(&MockWriter{}, nil)
+	slog.New(h).Info("msg")
+
+	if got := h.Recent(); got != nil {
+		t.Errorf("Expected no recent records, got %q", got)
+	}
+}
+
+func testDumpRecentOnPanic(t *testing.T) {
+	h := NewHandler(&MockWriter{}, &Options{TimeFormat: "[]", RecentRecords: 10})
+	slog.New(h).Info("before panic")
+
+	var dump bytes.Buffer
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("Expected panic to be propagated, got %v", r)
+			}
+		}()
+		defer h.DumpRecentOnPanic(&dump)
+
+		panic("boom")
+	}()
+
+	expected := "recent log records:\n[]  INFO  before panic\n"
+
+	if dump.String() != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, dump.String())
+	}
+}
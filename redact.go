@@ -0,0 +1,228 @@
+package humanslog
+
+import (
+	"encoding/json"
+	"log/slog"
+	"path"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// RedactOptions configures masking of attribute values before rendering
+type RedactOptions struct {
+	// Case-insensitive glob patterns of keys whose values are masked, also struct field names and map keys (e.g. "password", "*_token")
+	Keys []string
+
+	// Regular expressions of keys whose values are masked
+	KeyPatterns []*regexp.Regexp
+
+	// Detectors of sensitive parts of string values, e.g. CreditCardDetector
+	Detectors []Detector
+
+	// Text shown instead of masked values, "[REDACTED]" by default
+	Replacement string
+}
+
+// Detector finds sensitive parts of string values
+type Detector struct {
+	Name    string
+	Pattern *regexp.Regexp
+
+	// Optional check of a match, e.g. a checksum, to avoid false positives
+	Valid func(match string) bool
+}
+
+var (
+	// CreditCardDetector finds card numbers passing the Luhn check
+	CreditCardDetector = Detector{
+		Name:    "credit-card",
+		Pattern: regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`),
+		Valid:   luhnValid,
+	}
+
+	// EmailDetector finds email addresses
+	EmailDetector = Detector{
+		Name:    "email",
+		Pattern: regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`),
+	}
+
+	// BearerTokenDetector finds bearer tokens, e.g. in Authorization headers
+	BearerTokenDetector = Detector{
+		Name:    "bearer-token",
+		Pattern: regexp.MustCompile(`\b[Bb]earer\s+[A-Za-z0-9\-._~+/]+=*`),
+	}
+)
+
+func luhnValid(s string) bool {
+	sum := 0
+	double := false
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+
+	return sum%10 == 0
+}
+
+func (h *Handler) redactReplacement() string {
+	if h.opts.Redact != nil && h.opts.Redact.Replacement != "" {
+		return h.opts.Redact.Replacement
+	}
+
+	return "[REDACTED]"
+}
+
+// redactKey reports whether values of the key are masked
+func (h *Handler) redactKey(key string) bool {
+	if h.opts.Redact == nil {
+		return false
+	}
+
+	lower := strings.ToLower(key)
+	for _, k := range h.opts.Redact.Keys {
+		if ok, _ := path.Match(strings.ToLower(k), lower); ok {
+			return true
+		}
+	}
+
+	for _, re := range h.opts.Redact.KeyPatterns {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redactString masks parts of s found by detectors, keys and values of JSON objects are masked too
+func (h *Handler) redactString(s string) string {
+	if h.opts.Redact == nil {
+		return s
+	}
+
+	if h.isJSON(s) {
+		if redacted, ok := h.redactJSON(s); ok {
+			return redacted
+		}
+	}
+
+	for _, d := range h.opts.Redact.Detectors {
+		s = d.Pattern.ReplaceAllStringFunc(s, func(m string) string {
+			if d.Valid != nil && !d.Valid(m) {
+				return m
+			}
+			return h.redactReplacement()
+		})
+	}
+
+	return s
+}
+
+// redactJSON masks keys and values of the JSON document s, the original is returned when nothing is masked
+func (h *Handler) redactJSON(s string) (string, bool) {
+	var v any
+	d := json.NewDecoder(strings.NewReader(s))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return s, false
+	}
+
+	v, changed := h.redactJSONValue(v)
+	if !changed {
+		return s, true
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return s, false
+	}
+
+	return string(b), true
+}
+
+// redactJSONValue masks v in place and reports whether anything was masked
+func (h *Handler) redactJSONValue(v any) (any, bool) {
+	changed := false
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			if h.redactKey(k) {
+				v[k] = h.redactReplacement()
+				changed = true
+			} else if e, ok := h.redactJSONValue(e); ok {
+				v[k] = e
+				changed = true
+			}
+		}
+	case []any:
+		for i, e := range v {
+			if e, ok := h.redactJSONValue(e); ok {
+				v[i] = e
+				changed = true
+			}
+		}
+	case string:
+		if r := h.redactString(v); r != v {
+			return r, true
+		}
+	case json.Number:
+		// long numbers, e.g. card numbers, are detected like strings
+		if r := h.redactString(string(v)); r != string(v) {
+			return r, true
+		}
+	}
+
+	return v, changed
+}
+
+// redactAttrs masks values of matching keys and sensitive parts of strings,
+// fields of structs and maps are masked while rendering
+func (h *Handler) redactAttrs(as attributes) attributes {
+	if h.opts.Redact == nil {
+		return as
+	}
+
+	redacted := make(attributes, len(as))
+	for i, a := range as {
+		switch {
+		case h.redactKey(a.Key):
+			a.Value = slog.StringValue(h.redactReplacement())
+		case a.Value.Kind() == slog.KindGroup:
+			a.Value = slog.GroupValue(h.redactAttrs(a.Value.Group())...)
+		case a.Value.Kind() == slog.KindString:
+			a.Value = slog.StringValue(h.redactString(a.Value.String()))
+		case a.Value.Kind() == slog.KindAny:
+			if rv := reflect.ValueOf(a.Value.Any()); rv.Kind() == reflect.String {
+				if s := h.redactString(rv.String()); s != rv.String() {
+					a.Value = slog.StringValue(s)
+				}
+			}
+		}
+
+		redacted[i] = a
+	}
+
+	return redacted
+}
+
+// redactedField returns the masked value of a struct field or map entry, or nil when it isn't masked
+func (h *Handler) redactedField(name string) []byte {
+	if !h.redactKey(name) {
+		return nil
+	}
+
+	return h.colorStringFainted([]byte(h.redactReplacement()), fgWhite)
+}
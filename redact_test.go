@@ -0,0 +1,140 @@
+package humanslog
+
+import (
+	"log/slog"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func Test_Redact(t *testing.T) {
+	testRedactKeys(t)
+	testRedactDetectors(t)
+	testRedactStructAndMap(t)
+	testRedactJSON(t)
+	testRedactJSONUnchanged(t)
+	testRedactJSONNumber(t)
+	testLuhnValid(t)
+}
+
+func testRedactKeys(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{
+		TimeFormat: "[]",
+		NoColor:    true,
+		Redact: &RedactOptions{
+			Keys:        []string{"password", "*_TOKEN"},
+			KeyPatterns: []*regexp.Regexp{regexp.MustCompile(`^pin$`)},
+		},
+	}))
+
+	logger.Info("login", "user", "alice", "Password", "hunter2", "pin", 1234, slog.Group("auth", "refresh_token", "abc"))
+
+	expected := "[]  INFO  login user=alice Password=[REDACTED] pin=[REDACTED] auth.refresh_token=[REDACTED]\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testRedactDetectors(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{
+		TimeFormat: "[]",
+		NoColor:    true,
+		Redact: &RedactOptions{
+			Detectors:   []Detector{CreditCardDetector, EmailDetector, BearerTokenDetector},
+			Replacement: "***",
+		},
+	}))
+
+	logger.Info("payment", "card", "4111 1111 1111 1111", "order", "1234567890123", "contact", "mail alice@example.com", "auth", "Bearer abc.def")
+
+	expected := "[]  INFO  payment card=*** order=1234567890123 contact=mail *** auth=***\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testRedactStructAndMap(t *testing.T) {
+	type credentials struct {
+		User     string
+		Password string
+		Emails   []string
+	}
+
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{
+		TimeFormat:     "[]",
+		NoColor:        true,
+		EscapeNewlines: true,
+		Redact: &RedactOptions{
+			Keys:      []string{"password", "secret"},
+			Detectors: []Detector{EmailDetector},
+		},
+	}))
+
+	logger.Info("msg",
+		"c", credentials{User: "alice", Password: "hunter2", Emails: []string{"a@example.com"}},
+		"m", map[string]string{"secret": "x", "name": "y"},
+	)
+
+	got := string(w.WrittenData)
+	for _, leaked := range []string{"hunter2", "a@example.com", "=x"} {
+		if strings.Contains(got, leaked) {
+			t.Errorf("Expected %q to be redacted, got:\n%s", leaked, got)
+		}
+	}
+
+	if !strings.Contains(got, "User=alice") || !strings.Contains(got, "name=y") {
+		t.Errorf("Expected other fields to be kept, got:\n%s", got)
+	}
+}
+
+func testRedactJSON(t *testing.T) {
+	h := NewHandler(&MockWriter{}, &Options{Redact: &RedactOptions{Keys: []string{"password"}, Detectors: []Detector{EmailDetector}}})
+
+	got := h.redactString(`{"user":{"password":"hunter2","email":"a@example.com","id":12345678901234567890}}`)
+	expected := `{"user":{"email":"[REDACTED]","id":12345678901234567890,"password":"[REDACTED]"}}`
+
+	if got != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s", expected, got)
+	}
+}
+
+func testRedactJSONUnchanged(t *testing.T) {
+	h := NewHandler(&MockWriter{}, &Options{Redact: &RedactOptions{Keys: []string{"password"}, Detectors: []Detector{EmailDetector}}})
+
+	expected := `{ "b": 1.50, "a": [1, 2] }`
+	got := h.redactString(expected)
+
+	if got != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s", expected, got)
+	}
+}
+
+func testRedactJSONNumber(t *testing.T) {
+	h := NewHandler(&MockWriter{}, &Options{Redact: &RedactOptions{Detectors: []Detector{CreditCardDetector}}})
+
+	got := h.redactString(`{"card":4111111111111111,"id":7}`)
+	expected := `{"card":"[REDACTED]","id":7}`
+
+	if got != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s", expected, got)
+	}
+}
+
+func testLuhnValid(t *testing.T) {
+	tests := map[string]bool{
+		"4111111111111111":    true,
+		"4111-1111-1111-1111": true,
+		"4111111111111112":    false,
+	}
+
+	for s, expected := range tests {
+		if got := luhnValid(s); got != expected {
+			t.Errorf("luhnValid(%q): expected %v, got %v", s, expected, got)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package humanslog
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+)
+
+// newReplayHandler returns a JSON handler appending every record to the file, records aren't
+// passed through ReplaceAttr and aren't filtered by level, the file stays open for the life of the process
+func newReplayHandler(path string, addSource bool) slog.Handler {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return failedHandler{err: fmt.Errorf("humanslog: opening replay file: %w", err)}
+	}
+
+	return slog.NewJSONHandler(f, &slog.HandlerOptions{AddSource: addSource, Level: slog.Level(math.MinInt)})
+}
+
+// failedHandler reports err for every record
+type failedHandler struct {
+	err error
+}
+
+func (f failedHandler) Enabled(context.Context, slog.Level) bool  { return true }
+func (f failedHandler) Handle(context.Context, slog.Record) error { return f.err }
+func (f failedHandler) WithAttrs([]slog.Attr) slog.Handler        { return f }
+func (f failedHandler) WithGroup(string) slog.Handler             { return f }
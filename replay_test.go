@@ -0,0 +1,72 @@
+package humanslog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func Test_ReplayFile(t *testing.T) {
+	testReplayFile(t)
+	testReplayFileError(t)
+}
+
+func testReplayFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "replay.jsonl")
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, ReplayFile: path, ExcludePattern: regexp.MustCompile(`health`)}))
+
+	logger.With("service", "api").WithGroup("req").Info("request", "path", "/users")
+	logger.Info("healthcheck")
+
+	expected := "[]  INFO  request req.path=/users service=api\n"
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 replayed records, got:\n%s", data)
+	}
+
+	var first struct {
+		Msg     string
+		Service string
+		Req     struct{ Path string }
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatal(err)
+	}
+	if first.Msg != "request" || first.Service != "api" || first.Req.Path != "/users" {
+		t.Errorf("Unexpected replayed record: %s", lines[0])
+	}
+	if !strings.Contains(lines[1], `"msg":"healthcheck"`) {
+		t.Errorf("Unexpected replayed record: %s", lines[1])
+	}
+}
+
+func testReplayFileError(t *testing.T) {
+	w := &MockWriter{}
+	h := NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, ReplayFile: filepath.Join(t.TempDir(), "missing", "replay.jsonl")})
+
+	var r slog.Record
+	r.Message = "msg"
+	if err := h.Handle(context.Background(), r); err == nil || !strings.Contains(err.Error(), "replay file") {
+		t.Errorf("Expected replay file error, got %v", err)
+	}
+
+	if !bytes.Contains(w.WrittenData, []byte("msg")) {
+		t.Errorf("Expected the record to be written, got %q", w.WrittenData)
+	}
+}
@@ -0,0 +1,126 @@
+package humanslog
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// RotatingWriter writes plain text to a file, rotated when it would exceed its max size.
+// It's a ColorWriter which is never colored, so it can be combined with a colored terminal by NewMultiColorWriter.
+type RotatingWriter struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	f          *os.File
+	size       int64
+}
+
+// NewRotatingWriter returns a writer appending to the file at path with ANSI escape sequences stripped.
+// When a write would make the file larger than maxSize bytes, it's renamed to path.1, older backups are shifted
+// to path.2 and so on, and backups over maxBackups are removed. maxSize 0 disables the rotation.
+func NewRotatingWriter(path string, maxSize int64, maxBackups int) (*RotatingWriter, error) {
+	rw := &RotatingWriter{path: path, maxSize: maxSize, maxBackups: max(maxBackups, 0)}
+	if err := rw.open(); err != nil {
+		return nil, err
+	}
+
+	return rw, nil
+}
+
+func (rw *RotatingWriter) open() error {
+	f, err := os.OpenFile(rw.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		return errors.Join(err, f.Close())
+	}
+
+	rw.f, rw.size = f, fi.Size()
+	return nil
+}
+
+// Colored reports false, colors are never written to files
+func (rw *RotatingWriter) Colored() bool {
+	return false
+}
+
+func (rw *RotatingWriter) Write(p []byte) (int, error) {
+	plain := stripANSI(p)
+
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+
+	if rw.f == nil {
+		return 0, os.ErrClosed
+	}
+
+	if rw.maxSize > 0 && rw.size > 0 && rw.size+int64(len(plain)) > rw.maxSize {
+		if err := rw.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := rw.f.Write(plain)
+	rw.size += int64(n)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// rotate shifts the backups and starts a new file, rw.mu must be held. When the file can't be moved,
+// it's opened again, so only the current write fails.
+func (rw *RotatingWriter) rotate() error {
+	err := rw.f.Close()
+	rw.f = nil
+	if err == nil {
+		err = rw.shiftBackups()
+	}
+
+	return errors.Join(err, rw.open())
+}
+
+// shiftBackups moves the closed file to the first backup and the backups to the next ones
+func (rw *RotatingWriter) shiftBackups() error {
+	if rw.maxBackups == 0 {
+		if err := os.Remove(rw.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+
+		return nil
+	}
+
+	for i := rw.maxBackups - 1; i >= 1; i-- {
+		err := os.Rename(rw.backupPath(i), rw.backupPath(i+1))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	return os.Rename(rw.path, rw.backupPath(1))
+}
+
+func (rw *RotatingWriter) backupPath(i int) string {
+	return rw.path + "." + strconv.Itoa(i)
+}
+
+// Close closes the file, writes after Close fail
+func (rw *RotatingWriter) Close() error {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+
+	if rw.f == nil {
+		return nil
+	}
+
+	err := rw.f.Close()
+	rw.f = nil
+	return err
+}
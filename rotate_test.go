@@ -0,0 +1,115 @@
+package humanslog
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_RotatingWriter(t *testing.T) {
+	testRotatingWriter(t)
+	testRotatingWriterMirror(t)
+	testRotatingWriterRenameError(t)
+}
+
+func testRotatingWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dev.log")
+	rw, err := NewRotatingWriter(path, 10, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rw.Close()
+
+	for _, s := range []string{"\x1b[31maaaaaa\x1b[0m\n", "bbbbbb\n", "cccccc\n", "dddddd\n"} {
+		if n, err := rw.Write([]byte(s)); err != nil || n != len(s) {
+			t.Fatalf("Write(%q) = %d, %v", s, n, err)
+		}
+	}
+
+	expected := map[string]string{
+		path:        "dddddd\n",
+		path + ".1": "cccccc\n",
+		path + ".2": "bbbbbb\n",
+	}
+	for p, content := range expected {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != content {
+			t.Errorf("Expected %s to contain %q, got %q", filepath.Base(p), content, data)
+		}
+	}
+
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("Expected no third backup, got %v", err)
+	}
+}
+
+func testRotatingWriterMirror(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dev.log")
+	rw, err := NewRotatingWriter(path, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rw.Close()
+
+	term := &MockWriter{}
+	out := NewMultiColorWriter(NewColorWriter(term, true), rw)
+	slog.New(NewHandler(out, &Options{TimeFormat: "[]"})).Info("msg")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != "[]  INFO  msg\n" {
+		t.Errorf("Expected plain text in the file, got %q", data)
+	}
+	if string(term.WrittenData) == string(data) {
+		t.Errorf("Expected colored terminal output, got %q", term.WrittenData)
+	}
+}
+
+func testRotatingWriterRenameError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dev.log")
+	rw, err := NewRotatingWriter(path, 10, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rw.Close()
+
+	// a directory in place of the backup makes the rename fail
+	if err := os.MkdirAll(filepath.Join(path+".1", "dir"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := rw.Write([]byte("aaaaaa\n")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rw.Write([]byte("bbbbbb\n")); err == nil {
+		t.Error("Expected an error when the file can't be rotated")
+	}
+
+	if err := os.RemoveAll(path + ".1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rw.Write([]byte("cccccc\n")); err != nil {
+		t.Fatalf("Expected writes after a failed rotation to succeed, got %v", err)
+	}
+
+	expected := map[string]string{
+		path:        "cccccc\n",
+		path + ".1": "aaaaaa\n",
+	}
+	for p, content := range expected {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != content {
+			t.Errorf("Expected %s to contain %q, got %q", filepath.Base(p), content, data)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package humanslog
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// SamplingRate limits identical records (same level and message) of a level
+type SamplingRate struct {
+	// Number of identical records logged per Interval, the rest is suppressed
+	Burst int
+
+	// Length of the sampling window, 1s by default
+	Interval time.Duration
+}
+
+type samplingKey struct {
+	level   slog.Level
+	message string
+}
+
+type samplingWindow struct {
+	start      time.Time
+	interval   time.Duration
+	count      int
+	suppressed int
+}
+
+// suppressedRecords is the number of identical records suppressed in a window
+type suppressedRecords struct {
+	key   samplingKey
+	count int
+}
+
+// sampler counts identical records in windows, it's shared by handlers derived with With and WithGroup
+type sampler struct {
+	mu      sync.Mutex
+	windows map[samplingKey]*samplingWindow
+
+	// earliest end of a window with suppressed records, zero when there is none
+	due time.Time
+}
+
+func newSampler() *sampler {
+	return &sampler{windows: map[samplingKey]*samplingWindow{}}
+}
+
+// allow reports whether the record should be logged
+func (s *sampler) allow(rate SamplingRate, level slog.Level, message string, t time.Time) bool {
+	interval := rate.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := samplingKey{level: level, message: message}
+	w, ok := s.windows[key]
+	if !ok {
+		s.prune(t)
+		w = &samplingWindow{start: t, interval: interval}
+		s.windows[key] = w
+	}
+
+	if t.Sub(w.start) >= interval {
+		*w = samplingWindow{start: t, interval: interval}
+	}
+
+	if w.count >= rate.Burst {
+		w.suppressed++
+		if end := w.start.Add(interval); s.due.IsZero() || end.Before(s.due) {
+			s.due = end
+		}
+		return false
+	}
+
+	w.count++
+	return true
+}
+
+// takeSuppressed returns and resets the counts of suppressed records of windows ended by t, or of all windows
+// when all is set, so records suppressed by a flood are reported also when the flood stops
+func (s *sampler) takeSuppressed(t time.Time, all bool) []suppressedRecords {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.due.IsZero() || !all && t.Before(s.due) {
+		return nil
+	}
+
+	var taken []suppressedRecords
+	s.due = time.Time{}
+	for k, w := range s.windows {
+		if w.suppressed == 0 {
+			continue
+		}
+
+		end := w.start.Add(w.interval)
+		if all || !t.Before(end) {
+			taken = append(taken, suppressedRecords{key: k, count: w.suppressed})
+			w.suppressed = 0
+		} else if s.due.IsZero() || end.Before(s.due) {
+			s.due = end
+		}
+	}
+
+	sort.Slice(taken, func(i, j int) bool {
+		if taken[i].key.level != taken[j].key.level {
+			return taken[i].key.level < taken[j].key.level
+		}
+		return taken[i].key.message < taken[j].key.message
+	})
+
+	return taken
+}
+
+// prune drops expired windows without suppressed records, so the map doesn't grow with unique messages
+func (s *sampler) prune(t time.Time) {
+	if len(s.windows) < 1024 {
+		return
+	}
+
+	for k, w := range s.windows {
+		if w.suppressed == 0 && t.Sub(w.start) >= w.interval {
+			delete(s.windows, k)
+		}
+	}
+}
+
+// sample reports whether r should be logged, and appends the summaries of records
+// suppressed in windows ended before r to b
+func (h *Handler) sample(ctx context.Context, b []byte, r *slog.Record) ([]byte, bool) {
+	if h.sampler == nil {
+		return b, true
+	}
+
+	t := r.Time
+	if t.IsZero() {
+		t = time.Now()
+	}
+
+	b = h.appendSuppressed(ctx, b, h.sampler.takeSuppressed(t, false), r.Time)
+
+	rate, ok := h.opts.Sampling[r.Level]
+	if !ok {
+		return b, true
+	}
+
+	return b, h.sampler.allow(rate, r.Level, r.Message, t)
+}
+
+// appendSuppressed appends a summary record for each count of suppressed records
+func (h *Handler) appendSuppressed(ctx context.Context, b []byte, suppressed []suppressedRecords, t time.Time) []byte {
+	for _, s := range suppressed {
+		summary := slog.NewRecord(t, s.key.level, "suppressed "+strconv.Itoa(s.count)+" similar: "+s.key.message, 0)
+		b = h.formatRecord(ctx, b, &summary)
+	}
+
+	return b
+}
+
+// flushSuppressed writes the summaries of all records suppressed so far
+func (h *Handler) flushSuppressed() error {
+	if h.sampler == nil {
+		return nil
+	}
+
+	suppressed := h.sampler.takeSuppressed(time.Now(), true)
+	if len(suppressed) == 0 {
+		return nil
+	}
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	*buf = h.appendSuppressed(context.Background(), (*buf)[:0], suppressed, time.Now())
+	return h.output(*buf)
+}
@@ -0,0 +1,134 @@
+package humanslog
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func Test_Sampling(t *testing.T) {
+	testSamplingBurst(t)
+	testSamplingOtherLevels(t)
+	testSamplingSharedWithDerived(t)
+	testSamplingFloodStops(t)
+	testSamplingFlush(t)
+}
+
+func logAt(h slog.Handler, at time.Time, level slog.Level, msg string) {
+	_ = h.Handle(context.Background(), slog.NewRecord(at, level, msg, 0))
+}
+
+func testSamplingBurst(t *testing.T) {
+	w := &MockWriter{}
+	h := NewHandler(w, &Options{
+		HandlerOptions: &slog.HandlerOptions{Level: slog.LevelDebug},
+		TimeFormat:     "[05.000]",
+		NoColor:        true,
+		Sampling:       map[slog.Level]SamplingRate{slog.LevelDebug: {Burst: 2}},
+	})
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		logAt(h, start.Add(time.Duration(i)*100*time.Millisecond), slog.LevelDebug, "tick")
+	}
+	logAt(h, start.Add(200*time.Millisecond), slog.LevelDebug, "other")
+	logAt(h, start.Add(1500*time.Millisecond), slog.LevelDebug, "tick")
+
+	expected := "[00.000]  DEBUG  tick\n" +
+		"[00.100]  DEBUG  tick\n" +
+		"[00.200]  DEBUG  other\n" +
+		"[01.500]  DEBUG  suppressed 3 similar: tick\n" +
+		"[01.500]  DEBUG  tick\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testSamplingOtherLevels(t *testing.T) {
+	w := &MockWriter{}
+	h := NewHandler(w, &Options{
+		TimeFormat: "[]",
+		NoColor:    true,
+		Sampling:   map[slog.Level]SamplingRate{slog.LevelDebug: {Burst: 1}},
+	})
+
+	now := time.Now()
+	logAt(h, now, slog.LevelInfo, "msg")
+	logAt(h, now, slog.LevelInfo, "msg")
+
+	expected := "[]  INFO  msg\n[]  INFO  msg\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testSamplingSharedWithDerived(t *testing.T) {
+	w := &MockWriter{}
+	h := NewHandler(w, &Options{
+		TimeFormat: "[]",
+		NoColor:    true,
+		Sampling:   map[slog.Level]SamplingRate{slog.LevelInfo: {Burst: 1, Interval: time.Minute}},
+	})
+
+	now := time.Now()
+	logAt(h, now, slog.LevelInfo, "msg")
+	logAt(h.WithAttrs([]slog.Attr{slog.Int("a", 1)}), now, slog.LevelInfo, "msg")
+
+	expected := "[]  INFO  msg\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testSamplingFloodStops(t *testing.T) {
+	w := &MockWriter{}
+	h := NewHandler(w, &Options{
+		TimeFormat: "[05.000]",
+		NoColor:    true,
+		Sampling:   map[slog.Level]SamplingRate{slog.LevelWarn: {Burst: 1}},
+	})
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		logAt(h, start.Add(time.Duration(i)*100*time.Millisecond), slog.LevelWarn, "retry")
+	}
+	logAt(h, start.Add(500*time.Millisecond), slog.LevelInfo, "waiting")
+	logAt(h, start.Add(2*time.Second), slog.LevelInfo, "done")
+
+	expected := "[00.000]  WARN  retry\n" +
+		"[00.500]  INFO  waiting\n" +
+		"[02.000]  WARN  suppressed 2 similar: retry\n" +
+		"[02.000]  INFO  done\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testSamplingFlush(t *testing.T) {
+	w := &MockWriter{}
+	h := NewHandler(w, &Options{
+		TimeFormat: "[]",
+		NoColor:    true,
+		Sampling:   map[slog.Level]SamplingRate{slog.LevelInfo: {Burst: 1, Interval: time.Hour}},
+	})
+
+	now := time.Now()
+	logAt(h, now, slog.LevelInfo, "msg")
+	logAt(h, now, slog.LevelInfo, "msg")
+
+	if err := h.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "[]  INFO  msg\n[]  INFO  suppressed 1 similar: msg\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
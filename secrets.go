@@ -0,0 +1,197 @@
+package humanslog
+
+import (
+	"encoding/json"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type secretPattern struct {
+	kind string
+	// the first submatch, if any, is a label kept in front of the mask
+	re *regexp.Regexp
+
+	// keyed patterns match ids and checksums too, they apply only to values of keys which look like secrets
+	keyed bool
+}
+
+// Common credential shapes recognized by MaskSecrets
+var secretPatterns = []secretPattern{
+	{kind: "private-key", re: regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`)},
+	{kind: "aws-access-key", re: regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{kind: "bearer-token", re: regexp.MustCompile(`\b[Bb]earer\s+[A-Za-z0-9\-._~+/]+=*`)},
+	{kind: "hex-secret", re: regexp.MustCompile(`\b(?:[0-9a-fA-F]{64}|[0-9a-fA-F]{32})\b`), keyed: true},
+	{kind: "hex-secret", re: regexp.MustCompile(`(?i)\b((?:secret|token|api_?key|password|passwd|auth)["']?\s*[:=]\s*["']?)(?:[0-9a-f]{64}|[0-9a-f]{32})\b`)},
+	{kind: "jwt", re: regexp.MustCompile(`\beyJ[A-Za-z0-9_\-]+\.eyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]*`)},
+}
+
+// Key names of values which are masked by keyed patterns, e.g. "api_token" or "ClientSecret"
+var secretKeyPattern = regexp.MustCompile(`(?i)secret|token|passw(or)?d|api_?key|auth|credential|signature|private`)
+
+// Candidates for MaskHighEntropy, checked by looksRandom
+var tokenPattern = regexp.MustCompile(`[A-Za-z0-9_\-=]{20,}`)
+
+// maskSecrets replaces parts of string values which look like credentials
+func (h *Handler) maskSecrets(as attributes, group []string) attributes {
+	if !h.opts.MaskSecrets {
+		return as
+	}
+
+	return as.mapStrings(group, func(group []string, key string, s string) string {
+		masked, _ := h.maskSecretString(s, group, key)
+		return masked
+	})
+}
+
+func (h *Handler) maskSecretString(s string, group []string, key string) (string, bool) {
+	found := false
+	for _, p := range secretPatterns {
+		if p.keyed && !secretKeyPattern.MatchString(key) || !p.re.MatchString(s) {
+			continue
+		}
+
+		found = true
+		s = p.re.ReplaceAllString(s, "${1}[REDACTED "+p.kind+"]")
+
+		if h.opts.OnSecretMasked != nil {
+			h.opts.OnSecretMasked(secretPath(group, key), p.kind)
+		}
+	}
+
+	if h.opts.MaskHighEntropy {
+		s = tokenPattern.ReplaceAllStringFunc(s, func(m string) string {
+			if !looksRandom(m) {
+				return m
+			}
+
+			found = true
+			if h.opts.OnSecretMasked != nil {
+				h.opts.OnSecretMasked(secretPath(group, key), "high-entropy")
+			}
+			return "[REDACTED high-entropy]"
+		})
+	}
+
+	return s, found
+}
+
+// secretPath returns the dotted key passed to OnSecretMasked
+func secretPath(group []string, key string) string {
+	return strings.Join(append(group[:len(group):len(group)], key), ".")
+}
+
+// maskNestedSecrets masks secrets in strings of structs, maps and slices, path is the attribute key with the groups,
+// fields, map keys and indexes leading to the string
+func (h *Handler) maskNestedSecrets(s string, path []string) string {
+	if !h.opts.MaskSecrets {
+		return s
+	}
+
+	var key string
+	if len(path) > 0 {
+		key = path[len(path)-1]
+		path = path[:len(path)-1]
+	}
+
+	masked, _ := h.maskSecretString(s, path, key)
+	return masked
+}
+
+// maskJSONSecrets masks secrets in the strings and numbers of the JSON document s, each under its key path below path,
+// so keyed patterns apply to nested keys like "api_token". Text which isn't JSON is masked as one string.
+func (h *Handler) maskJSONSecrets(s string, path []string) string {
+	if !h.opts.MaskSecrets {
+		return s
+	}
+
+	var v any
+	d := json.NewDecoder(strings.NewReader(s))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil || d.More() {
+		return h.maskNestedSecrets(s, path)
+	}
+
+	v, changed := h.maskJSONValue(v, path[:len(path):len(path)])
+	if !changed {
+		return s
+	}
+
+	var buf strings.Builder
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return h.maskNestedSecrets(s, path)
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// maskJSONValue masks v in place and reports whether anything was masked
+func (h *Handler) maskJSONValue(v any, path []string) (any, bool) {
+	changed := false
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			if e, ok := h.maskJSONValue(e, append(path, k)); ok {
+				v[k] = e
+				changed = true
+			}
+		}
+	case []any:
+		for i, e := range v {
+			if e, ok := h.maskJSONValue(e, append(path, strconv.Itoa(i))); ok {
+				v[i] = e
+				changed = true
+			}
+		}
+	case string:
+		if m := h.maskNestedSecrets(v, path); m != v {
+			return m, true
+		}
+	case json.Number:
+		if m := h.maskNestedSecrets(string(v), path); m != string(v) {
+			return m, true
+		}
+	}
+
+	return v, changed
+}
+
+// looksRandom reports whether the token mixes upper and lower case letters with digits in a way identifiers don't,
+// with high Shannon entropy
+func looksRandom(s string) bool {
+	var upper, lower, digits bool
+	switches := 0
+	prevDigit := false
+	counts := make(map[rune]int)
+	for i, r := range s {
+		isDigit := r >= '0' && r <= '9'
+		switch {
+		case isDigit:
+			digits = true
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		}
+		if i > 0 && isDigit != prevDigit {
+			switches++
+		}
+		prevDigit = isDigit
+		counts[r]++
+	}
+
+	if !upper || !lower || !digits || switches < 4 {
+		return false
+	}
+
+	entropy := 0.0
+	for _, c := range counts {
+		p := float64(c) / float64(len(s))
+		entropy -= p * math.Log2(p)
+	}
+
+	return entropy >= 3.5
+}
@@ -0,0 +1,155 @@
+package humanslog
+
+import (
+	"bytes"
+	"container/list"
+	"hash/maphash"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Number of error fingerprints tracked when ErrorSeenSize is 0
+const defaultErrorSeenSize = 256
+
+// errorTracker counts recent occurrences of errors by fingerprint, the least recently seen are evicted,
+// it's shared by handlers derived with With and WithGroup
+type errorTracker struct {
+	mu     sync.Mutex
+	window time.Duration
+	size   int
+	seed   maphash.Seed
+	lru    *list.List
+	byKey  map[uint64]*list.Element
+}
+
+// Number of buckets counting occurrences of an error within ErrorSeenWindow
+const seenBuckets = 60
+
+// seenError counts occurrences in buckets of window/seenBuckets, so its size doesn't grow with the rate of the error
+type seenError struct {
+	key    uint64
+	counts [seenBuckets]int
+	// Bucket of the latest occurrence, the time divided by the bucket width
+	last int64
+}
+
+func newErrorTracker(window time.Duration, size int) *errorTracker {
+	if size <= 0 {
+		size = defaultErrorSeenSize
+	}
+
+	return &errorTracker{window: window, size: size, seed: maphash.MakeSeed(), lru: list.New(), byKey: make(map[uint64]*list.Element)}
+}
+
+// seen records an occurrence of the fingerprint at t and returns the number of occurrences within the window
+func (e *errorTracker) seen(fingerprint string, t time.Time) int {
+	key := maphash.String(e.seed, fingerprint)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	width := max(int64(e.window/seenBuckets), 1)
+	bucket := t.UnixNano() / width
+
+	el, ok := e.byKey[key]
+	if !ok {
+		el = e.lru.PushFront(&seenError{key: key, last: bucket})
+		e.byKey[key] = el
+		if e.lru.Len() > e.size {
+			oldest := e.lru.Back()
+			e.lru.Remove(oldest)
+			delete(e.byKey, oldest.Value.(*seenError).key)
+		}
+	} else {
+		e.lru.MoveToFront(el)
+	}
+
+	s := el.Value.(*seenError)
+	if bucket > s.last {
+		// Buckets between the latest occurrence and now are outside the window
+		for b := s.last + 1; b <= min(bucket, s.last+seenBuckets); b++ {
+			s.counts[bucketIndex(b)] = 0
+		}
+		s.last = bucket
+	}
+	// Occurrences out of order are counted in the latest bucket
+	s.counts[bucketIndex(s.last)]++
+
+	n := 0
+	for _, c := range s.counts {
+		n += c
+	}
+
+	return n
+}
+
+// bucketIndex returns the index in seenError.counts of a bucket, also of times before 1970
+func bucketIndex(bucket int64) int {
+	return int((bucket%seenBuckets + seenBuckets) % seenBuckets)
+}
+
+// errorFingerprint returns the message and the error messages of records at the Error level or with error attributes,
+// and false for other records
+func (h *Handler) errorFingerprint(r *slog.Record) (string, bool) {
+	var errs []string
+	r.Attrs(func(a slog.Attr) bool {
+		if !isErrorAttr(a) {
+			return true
+		}
+
+		if err, ok := a.Value.Any().(error); ok && a.Value.Kind() == slog.KindAny {
+			errs = append(errs, string(h.formatError(err)))
+		} else {
+			errs = append(errs, a.Value.String())
+		}
+		return true
+	})
+
+	if len(errs) == 0 && r.Level < slog.LevelError {
+		return "", false
+	}
+
+	return r.Message + "\x00" + strings.Join(errs, "\x00"), true
+}
+
+// annotateSeen appends "(seen N× in last 60s)" to the first line of the record rendered at b[start:]
+// when its error was seen before within ErrorSeenWindow
+func (h *Handler) annotateSeen(b []byte, start int, r *slog.Record) []byte {
+	fingerprint, ok := h.errorFingerprint(r)
+	if !ok {
+		return b
+	}
+
+	t := r.Time
+	if t.IsZero() {
+		t = time.Now()
+	}
+
+	n := h.seen.seen(fingerprint, t)
+	if n < 2 {
+		return b
+	}
+
+	note := h.faintedText([]byte(h.glyphs(" (seen " + strconv.Itoa(n) + "× in last " + windowString(h.seen.window) + ")")))
+
+	end := len(b)
+	if i := bytes.IndexByte(b[start:], '\n'); i >= 0 {
+		end = start + i
+	}
+
+	rest := append([]byte(nil), b[end:]...)
+	b = append(b[:end], note...)
+	return append(b, rest...)
+}
+
+// windowString renders whole seconds as e.g. "60s" instead of "1m0s"
+func windowString(d time.Duration) string {
+	if d%time.Second == 0 {
+		return strconv.FormatInt(int64(d/time.Second), 10) + "s"
+	}
+
+	return d.String()
+}
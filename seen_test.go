@@ -0,0 +1,90 @@
+package humanslog
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func Test_ErrorSeen(t *testing.T) {
+	testErrorSeen(t)
+	testErrorSeenWindow(t)
+	testErrorSeenEvicts(t)
+	testErrorSeenNilPointer(t)
+}
+
+func testErrorSeen(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, ErrorSeenWindow: time.Minute}))
+
+	logger.Error("query failed")
+	logger.Info("ok")
+	logger.Error("query failed")
+	logger.Warn("retry", "err", "timeout")
+	logger.Warn("retry", "err", "refused")
+
+	expected := "[]  ERROR  query failed\n" +
+		"[]  INFO  ok\n" +
+		"[]  ERROR  query failed (seen 2× in last 60s)\n" +
+		"[]  WARN  retry err=timeout\n" +
+		"[]  WARN  retry err=refused\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testErrorSeenWindow(t *testing.T) {
+	w := &MockWriter{}
+	h := NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, ErrorSeenWindow: time.Minute, ASCIIOnly: true})
+
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, d := range []time.Duration{0, 30 * time.Second, 50 * time.Second, 100 * time.Second} {
+		r := slog.NewRecord(start.Add(d), slog.LevelError, "failed", 0)
+		r.AddAttrs(slog.String("err", "boom"))
+		_ = h.Handle(context.Background(), r)
+	}
+
+	expected := "[]  ERROR  failed err=boom\n" +
+		"[]  ERROR  failed err=boom (seen 2x in last 60s)\n" +
+		"[]  ERROR  failed err=boom (seen 3x in last 60s)\n" +
+		"[]  ERROR  failed err=boom (seen 2x in last 60s)\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testErrorSeenEvicts(t *testing.T) {
+	e := newErrorTracker(time.Minute, 2)
+	now := time.Now()
+
+	e.seen("a", now)
+	e.seen("b", now)
+	e.seen("c", now)
+
+	if n := e.seen("a", now); n != 1 {
+		t.Errorf("Expected the least recently seen error to be forgotten, got %d", n)
+	}
+	if n := e.seen("c", now); n != 2 {
+		t.Errorf("Expected 2, got %d", n)
+	}
+}
+
+type seenPointerError struct{ msg string }
+
+func (e *seenPointerError) Error() string { return e.msg }
+
+func testErrorSeenNilPointer(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, ErrorSeenWindow: time.Minute}))
+
+	logger.Error("failed", "err", (*seenPointerError)(nil))
+	logger.Error("failed", "err", (*seenPointerError)(nil))
+
+	if !bytes.Contains(w.WrittenData, []byte("(seen 2× in last 60s)")) {
+		t.Errorf("Expected the second error to be annotated, got %q", w.WrittenData)
+	}
+}
@@ -0,0 +1,51 @@
+package humanslog
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func Test_Separators(t *testing.T) {
+	testSeparatorsInline(t)
+	testSeparatorsMultiline(t)
+	testSeparatorsWrapped(t)
+}
+
+func testSeparatorsInline(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, KeyValueSeparator: ": ", AttrSeparator: " | ", InlineGroupMaxAttrs: 2}))
+
+	logger.Info("msg", slog.Int("a", 1), slog.String("b", "x"), slog.Group("g", slog.Int("c", 2), slog.Int("d", 3)))
+
+	expected := "[]  INFO  msg a: 1 | b: x | g: {c: 2 | d: 3}\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testSeparatorsMultiline(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, KeyValueSeparator: ": ", Format: FormatExpanded}))
+
+	logger.Info("msg", slog.Int("a", 1))
+
+	expected := "[]  INFO  msg\n# a: 1\n\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testSeparatorsWrapped(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, AttrSeparator: ", ", WrapWidth: 24}))
+
+	logger.Info("msg", slog.Int("a", 1), slog.Int("b", 2), slog.String("long", "value"))
+
+	expected := "[]  INFO  msg a=1, b=2\n          long=value\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
@@ -0,0 +1,12 @@
+//go:build !unix
+
+package humanslog
+
+import "log/slog"
+
+// NotifyLevelSignals makes SIGUSR1 lower the level of lv by one step (more verbose, down to Debug)
+// and SIGUSR2 raise it (up to Error), e.g. kill -USR1 <pid>. Call stop to restore the signal handling.
+// Without SIGUSR1 and SIGUSR2 on this platform it does nothing.
+func NotifyLevelSignals(lv *slog.LevelVar) (stop func()) {
+	return func() {}
+}
@@ -0,0 +1,38 @@
+//go:build unix
+
+package humanslog
+
+import (
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// NotifyLevelSignals makes SIGUSR1 lower the level of lv by one step (more verbose, down to Debug)
+// and SIGUSR2 raise it (up to Error), e.g. kill -USR1 <pid>. Call stop to restore the signal handling.
+func NotifyLevelSignals(lv *slog.LevelVar) (stop func()) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGUSR1, syscall.SIGUSR2)
+
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case sig := <-ch:
+				if sig == syscall.SIGUSR1 {
+					stepLevel(lv, -1)
+				} else {
+					stepLevel(lv, 1)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(ch)
+		close(done)
+	}
+}
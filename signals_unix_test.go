@@ -0,0 +1,29 @@
+//go:build unix
+
+package humanslog
+
+import (
+	"log/slog"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func Test_LevelSignals(t *testing.T) {
+	lv := &slog.LevelVar{}
+	stop := NotifyLevelSignals(lv)
+	defer stop()
+
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for lv.Level() != slog.LevelDebug && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	if lv.Level() != slog.LevelDebug {
+		t.Errorf("Expected Debug after SIGUSR1, got %s", lv.Level())
+	}
+}
@@ -0,0 +1,90 @@
+package humanslog
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"testing/slogtest"
+	"time"
+)
+
+func Test_Slogtest(t *testing.T) {
+	testSlogtest(t)
+	testZeroTime(t)
+}
+
+func testSlogtest(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewHandler(&buf, &Options{TimeFormat: time.RFC3339Nano, NoColor: true})
+
+	results := func() []map[string]any {
+		var ms []map[string]any
+		for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+			ms = append(ms, parseLine(t, line))
+		}
+		return ms
+	}
+
+	if err := slogtest.TestHandler(h, results); err != nil {
+		t.Error(err)
+	}
+}
+
+func testZeroTime(t *testing.T) {
+	w := &MockWriter{}
+	h := NewHandler(w, &Options{TimeFormat: "[]", NoColor: true})
+
+	if err := h.Handle(context.Background(), slog.NewRecord(time.Time{}, slog.LevelInfo, "msg", 0)); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := " INFO  msg\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+// parseLine parses a NoColor record rendered with RFC3339Nano time into a map for slogtest
+func parseLine(t *testing.T, line string) map[string]any {
+	m := map[string]any{}
+	fields := strings.Fields(line)
+
+	if len(fields) > 0 {
+		if ts, err := time.Parse(time.RFC3339Nano, fields[0]); err == nil {
+			m[slog.TimeKey] = ts
+			fields = fields[1:]
+		}
+	}
+	if len(fields) > 0 {
+		m[slog.LevelKey] = fields[0]
+		fields = fields[1:]
+	}
+	if len(fields) > 0 && !strings.Contains(fields[0], "=") {
+		m[slog.MessageKey] = fields[0]
+		fields = fields[1:]
+	}
+
+	for _, f := range fields {
+		k, v, ok := strings.Cut(f, "=")
+		if !ok {
+			t.Fatalf("unexpected field %q in %q", f, line)
+		}
+
+		path := strings.Split(k, ".")
+		cur := m
+		for _, p := range path[:len(path)-1] {
+			next, ok := cur[p].(map[string]any)
+			if !ok {
+				next = map[string]any{}
+				cur[p] = next
+			}
+			cur = next
+		}
+		cur[path[len(path)-1]] = v
+	}
+
+	return m
+}
@@ -0,0 +1,101 @@
+package humanslog
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// formatSourceSnippet appends dimmed source code around the logging line of Error records
+func (h *Handler) formatSourceSnippet(b []byte, r *slog.Record) []byte {
+	n := h.opts.SourceSnippetLines
+	if n <= 0 || !h.opts.AddSource || r.Level < slog.LevelError || r.PC == 0 {
+		return b
+	}
+
+	f, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+	first := max(f.Line-n, 1)
+	lines, err := readSourceLines(f.File, first, f.Line+n)
+	if err != nil || len(lines) == 0 {
+		return b
+	}
+
+	width := len(strconv.Itoa(first + len(lines) - 1))
+
+	b = bytes.TrimRight(b, "\n")
+	for i, line := range lines {
+		marker := " "
+		if first+i == f.Line {
+			marker = ">"
+		}
+
+		b = append(b, '\n')
+		b = append(b, h.faintedText([]byte(fmt.Sprintf("    %s %*d | %s", marker, width, first+i, h.expandTabs(line))))...)
+	}
+
+	return append(b, '\n')
+}
+
+// readSourceLines returns lines from first to last (1-based, inclusive) of the file
+func readSourceLines(file string, first, last int) ([]string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []string
+	s := bufio.NewScanner(f)
+	for n := 1; n <= last && s.Scan(); n++ {
+		if n >= first {
+			lines = append(lines, s.Text())
+		}
+	}
+
+	return lines, s.Err()
+}
+
+// sourceString returns the source location formatted by SourceFormatter,
+// the editor command when EditorCommandTemplate is set, or file:line shortened according to SourcePath
+func (h *Handler) sourceString(s *slog.Source) string {
+	if h.opts.SourceFormatter != nil {
+		return h.opts.SourceFormatter(s)
+	}
+
+	if h.opts.EditorCommandTemplate != "" {
+		return editorCommand(h.opts.EditorCommandTemplate, s.File, s.Line)
+	}
+
+	return h.sourcePath(s.File) + ":" + strconv.Itoa(s.Line)
+}
+
+// editorCommand expands %f, %l and %% in the template
+func editorCommand(template string, file string, line int) string {
+	var b strings.Builder
+	for i := 0; i < len(template); i++ {
+		if template[i] != '%' || i+1 == len(template) {
+			b.WriteByte(template[i])
+			continue
+		}
+
+		i++
+		switch template[i] {
+		case 'f':
+			b.WriteString(file)
+		case 'l':
+			b.WriteString(strconv.Itoa(line))
+		case '%':
+			b.WriteByte('%')
+		default:
+			b.WriteByte('%')
+			b.WriteByte(template[i])
+		}
+	}
+
+	return b.String()
+}
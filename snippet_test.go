@@ -0,0 +1,75 @@
+package humanslog
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func Test_Snippet(t *testing.T) {
+	testSourceSnippet(t)
+	testSourceSnippetBelowError(t)
+	testEditorCommandTemplate(t)
+	testEditorCommand(t)
+}
+
+func testSourceSnippet(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{HandlerOptions: &slog.HandlerOptions{AddSource: true}, TimeFormat: "[]", NoColor: true, SourceSnippetLines: 1}))
+
+	_, _, line, _ := runtime.Caller(0)
+	logger.Error("msg")
+
+	got := string(w.WrittenData)
+	snippet := got[strings.Index(got, "\n")+1:]
+	expected := fmt.Sprintf("      %d | \t_, _, line, _ := runtime.Caller(0)\n    > %d | \tlogger.Error(\"msg\")\n      %d | \n\n", line, line+1, line+2)
+
+	if snippet != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, snippet)
+	}
+}
+
+func testSourceSnippetBelowError(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{HandlerOptions: &slog.HandlerOptions{AddSource: true}, TimeFormat: "[]", NoColor: true, SourceSnippetLines: 2}))
+
+	logger.Warn("msg")
+
+	if bytes.Count(w.WrittenData, []byte("\n")) != 1 {
+		t.Errorf("\nExpected single line\nGot:\n%s", w.WrittenData)
+	}
+}
+
+func testEditorCommandTemplate(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{HandlerOptions: &slog.HandlerOptions{AddSource: true}, TimeFormat: "[]", NoColor: true, EditorCommandTemplate: "code -g %f:%l"}))
+
+	_, file, line, _ := runtime.Caller(0)
+	logger.Info("msg")
+
+	expected := fmt.Sprintf("[] code -g %s:%d  INFO  msg\n", file, line+1)
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testEditorCommand(t *testing.T) {
+	tests := []struct {
+		template string
+		expected string
+	}{
+		{"vim +%l %f", "vim +12 a.go"},
+		{"idea --line %l %f", "idea --line 12 a.go"},
+		{"100%% %x %", "100% %x %"},
+	}
+
+	for _, tt := range tests {
+		if got := editorCommand(tt.template, "a.go", 12); got != tt.expected {
+			t.Errorf("editorCommand(%q): expected %q, got %q", tt.template, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package humanslog
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+func Test_SortMode(t *testing.T) {
+	testKeyOrderKeepsCallOrder(t)
+	testKeyOrderPriority(t)
+	testSortAlpha(t)
+	testSortKeysDefaultsToAlpha(t)
+}
+
+func testKeyOrderKeepsCallOrder(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, KeyOrder: []string{"err", "request_id"}}))
+
+	logger.Info("msg", "b", 1, "request_id", "r1", "a", 2, "err", "x")
+
+	expected := "[]  INFO  msg err=x request_id=r1 b=1 a=2\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testKeyOrderPriority(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, SortMode: SortPriority, KeyOrder: []string{"err"}}))
+
+	logger.Info("msg", "b", 1, "a", 2, "err", "x")
+
+	expected := "[]  INFO  msg err=x a=2 b=1\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testSortAlpha(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, SortMode: SortAlpha, KeyOrder: []string{"err"}}))
+
+	logger.Info("msg", "b", 1, "a", 2, "err", "x")
+
+	expected := "[]  INFO  msg a=2 b=1 err=x\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testSortKeysDefaultsToAlpha(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, SortKeys: true}))
+
+	logger.Info("msg", "b", 1, "a", 2)
+
+	expected := "[]  INFO  msg a=2 b=1\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
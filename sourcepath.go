@@ -0,0 +1,74 @@
+package humanslog
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// SourcePath is how the file of the source location is shown
+type SourcePath int
+
+const (
+	// SourcePathFull shows the absolute file path
+	SourcePathFull SourcePath = iota
+
+	// SourcePathRelative shows the file path relative to the module root containing go.mod
+	SourcePathRelative
+
+	// SourcePathShort shows only the package directory and file, e.g. pkg/file.go
+	SourcePathShort
+)
+
+// moduleRoots caches module root of directories, "" when there is no go.mod
+var moduleRoots sync.Map
+
+// moduleRoot returns the closest directory containing go.mod of dir
+func moduleRoot(dir string) string {
+	if root, ok := moduleRoots.Load(dir); ok {
+		return root.(string)
+	}
+
+	root := ""
+	for d := dir; ; {
+		if _, err := os.Stat(filepath.Join(d, "go.mod")); err == nil {
+			root = d
+			break
+		}
+
+		parent := filepath.Dir(d)
+		if parent == d {
+			break
+		}
+		d = parent
+	}
+
+	moduleRoots.Store(dir, root)
+	return root
+}
+
+// sourcePath returns the file path shortened according to SourcePath
+func (h *Handler) sourcePath(file string) string {
+	switch h.opts.SourcePath {
+	case SourcePathRelative:
+		root := moduleRoot(filepath.Dir(file))
+		if root == "" {
+			return file
+		}
+
+		if rel, err := filepath.Rel(root, file); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.ToSlash(rel)
+		}
+		return file
+	case SourcePathShort:
+		file = filepath.ToSlash(file)
+		if pkg := path.Base(path.Dir(file)); pkg != "." && pkg != "/" {
+			return pkg + "/" + path.Base(file)
+		}
+		return path.Base(file)
+	default:
+		return file
+	}
+}
@@ -0,0 +1,65 @@
+package humanslog
+
+import (
+	"fmt"
+	"log/slog"
+	"runtime"
+	"testing"
+)
+
+func Test_SourcePath(t *testing.T) {
+	testSourcePathRelative(t)
+	testSourcePathShort(t)
+	testSourceFormatter(t)
+}
+
+func testSourcePathRelative(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{HandlerOptions: &slog.HandlerOptions{AddSource: true}, TimeFormat: "[]", NoColor: true, SourcePath: SourcePathRelative}))
+
+	_, _, line, _ := runtime.Caller(0)
+	logger.Info("msg")
+
+	expected := fmt.Sprintf("[] sourcepath_test.go:%d  INFO  msg\n", line+1)
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testSourcePathShort(t *testing.T) {
+	h := NewHandler(&MockWriter{}, &Options{SourcePath: SourcePathShort})
+
+	tests := map[string]string{
+		"/home/user/app/internal/db/conn.go": "db/conn.go",
+		"conn.go":                            "conn.go",
+		"/conn.go":                           "conn.go",
+	}
+
+	for file, expected := range tests {
+		if got := h.sourcePath(file); got != expected {
+			t.Errorf("sourcePath(%q): expected %q, got %q", file, expected, got)
+		}
+	}
+}
+
+func testSourceFormatter(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{
+		HandlerOptions: &slog.HandlerOptions{AddSource: true},
+		TimeFormat:     "[]",
+		NoColor:        true,
+		SourceFormatter: func(s *slog.Source) string {
+			return fmt.Sprintf("<%d>", s.Line)
+		},
+	}))
+
+	_, _, line, _ := runtime.Caller(0)
+	logger.Info("msg")
+
+	expected := fmt.Sprintf("[] <%d>  INFO  msg\n", line+1)
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
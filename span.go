@@ -0,0 +1,82 @@
+package humanslog
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"time"
+)
+
+// Elapsed is a duration colored green below 100ms, yellow below 1s and red above, unless DurationThresholds match its key
+type Elapsed time.Duration
+
+func (e Elapsed) String() string {
+	return time.Duration(e).String()
+}
+
+func (h *Handler) elapsedColor(key string, e Elapsed) foregroundColor {
+	if c, ok := h.durationColor(key, time.Duration(e)); ok {
+		return c
+	}
+
+	return defaultDurationThresholds.color(time.Duration(e))
+}
+
+type spanDepthKey struct{}
+
+// spanDepth returns the number of spans started with StartContext in ctx
+func spanDepth(ctx context.Context) int {
+	if ctx == nil {
+		return 0
+	}
+
+	d, _ := ctx.Value(spanDepthKey{}).(int)
+	return d
+}
+
+// spanDepth returns the indentation depth of ctx, limited to MaxSpanDepth
+func (h *Handler) spanDepth(ctx context.Context) int {
+	d := spanDepth(ctx)
+	if h.opts.SpanDepth != nil && ctx != nil {
+		d = max(d, h.opts.SpanDepth(ctx))
+	}
+
+	return min(max(d, 0), h.opts.MaxSpanDepth)
+}
+
+// Start logs msg and returns a function which logs its completion with the elapsed time
+func Start(logger *slog.Logger, msg string, args ...any) func(args ...any) {
+	_, done := startSpan(context.Background(), logger, msg, args)
+	return done
+}
+
+// StartContext is like Start, records logged with the returned context are nested under the span
+// and indented when IndentSpans is enabled
+func StartContext(ctx context.Context, logger *slog.Logger, msg string, args ...any) (context.Context, func(args ...any)) {
+	return startSpan(ctx, logger, msg, args)
+}
+
+func startSpan(ctx context.Context, logger *slog.Logger, msg string, args []any) (context.Context, func(args ...any)) {
+	start := time.Now()
+	logSpan(ctx, logger, 4, msg, args)
+
+	spanCtx := context.WithValue(ctx, spanDepthKey{}, spanDepth(ctx)+1)
+
+	return spanCtx, func(doneArgs ...any) {
+		logSpan(ctx, logger, 3, msg+" done", append(doneArgs, slog.Any("elapsed", Elapsed(time.Since(start)))))
+	}
+}
+
+// logSpan logs with the source of the caller skip frames up, so it points at Start or done call
+func logSpan(ctx context.Context, logger *slog.Logger, skip int, msg string, args []any) {
+	if !logger.Enabled(ctx, slog.LevelInfo) {
+		return
+	}
+
+	var pcs [1]uintptr
+	runtime.Callers(skip, pcs[:])
+
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, msg, pcs[0])
+	r.Add(args...)
+	_ = logger.Handler().Handle(ctx, r)
+}
@@ -0,0 +1,121 @@
+package humanslog
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func Test_Span(t *testing.T) {
+	testStart(t)
+	testStartSource(t)
+	testStartContextIndent(t)
+	testSpanDepthFunc(t)
+	testNegativeMaxSpanDepth(t)
+	testElapsedColor(t)
+}
+
+func testStart(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true}))
+
+	done := Start(logger, "migrating db", slog.String("db", "main"))
+	done(slog.Int("tables", 3))
+
+	expected := regexp.MustCompile(`^\[\]  INFO  migrating db db=main\n\[\]  INFO  migrating db done tables=3 elapsed=[0-9.]+[nµm]?s\n$`)
+
+	if !expected.Match(w.WrittenData) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s", expected, w.WrittenData)
+	}
+}
+
+func testStartSource(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{HandlerOptions: &slog.HandlerOptions{AddSource: true}, TimeFormat: "[]", NoColor: true}))
+
+	_, file, line, _ := runtime.Caller(0)
+	done := Start(logger, "start")
+	done()
+
+	expected := regexp.MustCompile(fmt.Sprintf(`^\[\] %[1]s:%[2]d  INFO  start\n\[\] %[1]s:%[3]d  INFO  start done`, regexp.QuoteMeta(file), line+1, line+2))
+
+	if !expected.Match(w.WrittenData) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s", expected, w.WrittenData)
+	}
+}
+
+func testStartContextIndent(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, IndentSpans: true}))
+
+	ctx, done := StartContext(context.Background(), logger, "outer")
+	ctx2, done2 := StartContext(ctx, logger, "inner")
+	logger.InfoContext(ctx2, "step")
+	done2()
+	done()
+
+	expected := regexp.MustCompile(`^\[\]  INFO  outer\n` +
+		`\[\]  INFO    inner\n` +
+		`\[\]  INFO      step\n` +
+		`\[\]  INFO    inner done elapsed=\S+\n` +
+		`\[\]  INFO  outer done elapsed=\S+\n$`)
+
+	if !expected.Match(w.WrittenData) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s", expected, w.WrittenData)
+	}
+}
+
+func testElapsedColor(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]"}))
+
+	logger.Info("msg", slog.Any("fast", Elapsed(time.Millisecond)), slog.Any("slow", Elapsed(2*time.Second)))
+
+	expected := "\x1b[2m[]\x1b[0m \x1b[42m\x1b[30m INFO \x1b[0m msg \x1b[90mfast=\x1b[0m\x1b[32m1ms\x1b[0m \x1b[90mslow=\x1b[0m\x1b[31m2s\x1b[0m\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testSpanDepthFunc(t *testing.T) {
+	type depthKey struct{}
+
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{
+		TimeFormat:   "[]",
+		NoColor:      true,
+		IndentSpans:  true,
+		MaxSpanDepth: 2,
+		SpanDepth: func(ctx context.Context) int {
+			d, _ := ctx.Value(depthKey{}).(int)
+			return d
+		},
+	}))
+
+	logger.InfoContext(context.WithValue(context.Background(), depthKey{}, 1), "child")
+	logger.InfoContext(context.WithValue(context.Background(), depthKey{}, 5), "deep")
+
+	expected := "[]  INFO    child\n[]  INFO      deep\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testNegativeMaxSpanDepth(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, IndentSpans: true, MaxSpanDepth: -1}))
+
+	logger.InfoContext(context.WithValue(context.Background(), spanDepthKey{}, 1), "child")
+
+	expected := "[]  INFO    child\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
@@ -0,0 +1,257 @@
+package humanslog
+
+import (
+	"database/sql/driver"
+	"log/slog"
+	"reflect"
+	"strings"
+)
+
+var sqlStatements = map[string]bool{
+	"SELECT": true, "INSERT": true, "UPDATE": true, "DELETE": true, "WITH": true, "CREATE": true,
+	"ALTER": true, "DROP": true, "TRUNCATE": true, "MERGE": true, "REPLACE": true, "UPSERT": true,
+}
+
+var sqlKeywords = map[string]bool{
+	"ADD": true, "ALL": true, "ALTER": true, "AND": true, "AS": true, "ASC": true, "BEGIN": true, "BETWEEN": true,
+	"BY": true, "CASE": true, "COLUMN": true, "COMMIT": true, "CONFLICT": true, "CONSTRAINT": true, "CREATE": true,
+	"CROSS": true, "DEFAULT": true, "DELETE": true, "DESC": true, "DISTINCT": true, "DO": true, "DROP": true,
+	"ELSE": true, "END": true, "EXCEPT": true, "EXISTS": true, "FALSE": true, "FETCH": true, "FOR": true,
+	"FOREIGN": true, "FROM": true, "FULL": true, "GROUP": true, "HAVING": true, "IF": true, "ILIKE": true,
+	"IN": true, "INDEX": true, "INNER": true, "INSERT": true, "INTERSECT": true, "INTO": true, "IS": true,
+	"JOIN": true, "KEY": true, "LEFT": true, "LIKE": true, "LIMIT": true, "MERGE": true, "NOT": true,
+	"NOTHING": true, "NULL": true, "OFFSET": true, "ON": true, "OR": true, "ORDER": true, "OUTER": true,
+	"OVER": true, "PARTITION": true, "PRIMARY": true, "REFERENCES": true, "REPLACE": true, "RETURNING": true,
+	"RIGHT": true, "ROLLBACK": true, "SELECT": true, "SET": true, "TABLE": true, "THEN": true, "TRUE": true,
+	"TRUNCATE": true, "UNION": true, "UNIQUE": true, "UPDATE": true, "UPSERT": true, "USING": true,
+	"VALUES": true, "WHEN": true, "WHERE": true, "WINDOW": true, "WITH": true,
+}
+
+// sqlClauses start a new line when FormatSQL is enabled, sqlConditions are indented on their own line
+var (
+	sqlClauses = map[string]bool{
+		"FROM": true, "WHERE": true, "GROUP": true, "ORDER": true, "HAVING": true, "LIMIT": true, "OFFSET": true,
+		"JOIN": true, "LEFT": true, "RIGHT": true, "INNER": true, "FULL": true, "CROSS": true, "UNION": true,
+		"EXCEPT": true, "INTERSECT": true, "VALUES": true, "SET": true, "RETURNING": true,
+	}
+	sqlConditions = map[string]bool{"AND": true, "OR": true, "ON": true}
+)
+
+// looksLikeSQL reports whether s starts with a statement keyword followed by another keyword, e.g. SELECT ... FROM
+func looksLikeSQL(s string) bool {
+	words := strings.Fields(s)
+	if len(words) < 2 || !sqlStatements[strings.ToUpper(words[0])] {
+		return false
+	}
+
+	for _, w := range words[1:] {
+		if sqlKeywords[strings.ToUpper(strings.Trim(w, "(),;"))] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isSQL reports whether a string attribute is rendered as SQL, by key suffix in SQLKeys or detected with HighlightSQL
+func (h *Handler) isSQL(a slog.Attr) bool {
+	if a.Value.Kind() != slog.KindString {
+		return false
+	}
+
+	return hasKeySuffix(a.Key, h.opts.SQLKeys) || (h.opts.HighlightSQL && looksLikeSQL(a.Value.String()))
+}
+
+// isFormattedSQL reports whether the attribute is SQL reformatted across lines in the multiline section
+func (h *Handler) isFormattedSQL(a slog.Attr) bool {
+	return h.opts.FormatSQL && h.isSQL(a)
+}
+
+// appendSQL appends the query with highlighted keywords, literals and placeholders. With format, whitespace
+// is collapsed and clauses start on new lines prefixed with indent, otherwise lines after the first are prefixed with indent.
+func (h *Handler) appendSQL(b []byte, s string, indent string, format bool) []byte {
+	depth := 0
+	prevKeyword := ""
+	for i := 0; i < len(s); {
+		c := s[i]
+		end := i + 1
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			for end < len(s) && strings.IndexByte(" \t\r\n", s[end]) >= 0 {
+				end++
+			}
+
+			ws := s[i:end]
+			switch {
+			case format && i > 0 && end < len(s):
+				b = append(b, ' ')
+			case !format:
+				b = append(b, strings.ReplaceAll(ws, "\n", "\n"+indent)...)
+			}
+		case c == '\'' || c == '"' || c == '`':
+			for end < len(s) {
+				if s[end] == c {
+					// doubled quotes escape the quote
+					if end+1 < len(s) && s[end+1] == c {
+						end += 2
+						continue
+					}
+					end++
+					break
+				}
+				end++
+			}
+
+			if c == '\'' {
+				b = append(b, h.colorString([]byte(s[i:end]), fgGreen)...)
+			} else {
+				b = append(b, s[i:end]...)
+			}
+		case c == '-' && strings.HasPrefix(s[i:], "--"):
+			end = strings.IndexByte(s[i:], '\n')
+			if end < 0 {
+				end = len(s)
+			} else {
+				end += i
+			}
+			b = append(b, h.faintedText([]byte(s[i:end]))...)
+		case c == '/' && strings.HasPrefix(s[i:], "/*"):
+			end = strings.Index(s[i+2:], "*/")
+			if end < 0 {
+				end = len(s)
+			} else {
+				end += i + 4
+			}
+			b = append(b, h.faintedText([]byte(s[i:end]))...)
+		case c == '?' || ((c == '$' || c == ':' || c == '@') && end < len(s) && isSQLWordByte(s[end]) && !(c == ':' && i > 0 && s[i-1] == ':')):
+			for end < len(s) && isSQLWordByte(s[end]) {
+				end++
+			}
+			b = append(b, h.colorString([]byte(s[i:end]), fgYellow)...)
+		case c >= '0' && c <= '9':
+			for end < len(s) && (isSQLWordByte(s[end]) || s[end] == '.') {
+				end++
+			}
+			b = append(b, h.colorString([]byte(s[i:end]), fgCyan)...)
+		case isSQLWordByte(c):
+			for end < len(s) && isSQLWordByte(s[end]) {
+				end++
+			}
+
+			word := s[i:end]
+			upper := strings.ToUpper(word)
+			if !sqlKeywords[upper] {
+				b = append(b, word...)
+				prevKeyword = ""
+				break
+			}
+
+			if format && i > 0 {
+				b = h.breakSQLLine(b, upper, prevKeyword, indent, depth)
+			}
+			b = append(b, h.colorString([]byte(word), fgMagenta)...)
+			prevKeyword = upper
+		default:
+			switch c {
+			case '(':
+				depth++
+			case ')':
+				depth = max(depth-1, 0)
+			}
+			b = append(b, c)
+		}
+
+		i = end
+	}
+
+	return b
+}
+
+// breakSQLLine replaces the space before a clause keyword with a new line indented by the parenthesis depth
+func (h *Handler) breakSQLLine(b []byte, keyword, prevKeyword, indent string, depth int) []byte {
+	extra := 0
+	switch {
+	case sqlConditions[keyword]:
+		extra = 1
+	case sqlClauses[keyword]:
+		// keep multi-word clauses together, e.g. LEFT OUTER JOIN, UNION ALL
+		if sqlClauses[prevKeyword] {
+			return b
+		}
+	default:
+		return b
+	}
+
+	if len(b) > 0 && b[len(b)-1] == ' ' {
+		b = b[:len(b)-1]
+	}
+
+	b = append(b, '\n')
+	b = append(b, indent...)
+	return append(b, strings.Repeat("  ", depth+extra)...)
+}
+
+func isSQLWordByte(c byte) bool {
+	return c == '_' || c == '.' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c > 0x7f
+}
+
+// sqlNull is the value of a driver.Valuer storing NULL, e.g. an invalid sql.NullString
+type sqlNull struct{}
+
+// resolveValuers replaces values implementing driver.Valuer, like sql.NullInt64, by the value they store, also inside groups
+func resolveValuers(as attributes) attributes {
+	resolved := make(attributes, len(as))
+	for i, a := range as {
+		switch a.Value.Kind() {
+		case slog.KindGroup:
+			a.Value = slog.GroupValue(resolveValuers(a.Value.Group())...)
+		case slog.KindAny:
+			if v, ok := driverValue(a.Value.Any()); ok {
+				a.Value = v
+			}
+		}
+
+		resolved[i] = a
+	}
+
+	return resolved
+}
+
+// driverValue returns the value stored in a driver.Valuer, sqlNull for NULL. It reports false for other values,
+// types with a registered formatter and when Value fails.
+func driverValue(v any) (val slog.Value, ok bool) {
+	valuer, ok := v.(driver.Valuer)
+	if !ok {
+		return slog.Value{}, false
+	}
+
+	if _, _, registered := typeFormatter(reflect.ValueOf(v)); registered {
+		return slog.Value{}, false
+	}
+
+	// Value of a nil pointer panics
+	defer func() {
+		if recover() != nil {
+			val, ok = slog.Value{}, false
+		}
+	}()
+
+	x, err := valuer.Value()
+	if err != nil {
+		return slog.Value{}, false
+	}
+	if x == nil {
+		return slog.AnyValue(sqlNull{}), true
+	}
+	if _, nested := x.(driver.Valuer); nested {
+		return slog.Value{}, false
+	}
+
+	return slog.AnyValue(x), true
+}
+
+// nullText renders NULL of database values dimmed
+func (h *Handler) nullText() []byte {
+	return h.faintedText([]byte("NULL"))
+}
@@ -0,0 +1,133 @@
+package humanslog
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"log/slog"
+	"testing"
+)
+
+func Test_SQL(t *testing.T) {
+	testLooksLikeSQL(t)
+	testSQLKeysInline(t)
+	testHighlightSQL(t)
+	testFormatSQL(t)
+	testNullValues(t)
+	testNullValuesInStruct(t)
+	testNullValuesLogfmt(t)
+}
+
+func testLooksLikeSQL(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected bool
+	}{
+		{"SELECT * FROM users", true},
+		{"insert into t (a) values (1)", true},
+		{"with x as (select 1) select * from x", true},
+		{"select an option", false},
+		{"SELECT", false},
+		{"hello from the other side", false},
+	}
+
+	for _, tt := range tests {
+		if got := looksLikeSQL(tt.s); got != tt.expected {
+			t.Errorf("looksLikeSQL(%q) = %v, expected %v", tt.s, got, tt.expected)
+		}
+	}
+}
+
+func testSQLKeysInline(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, SQLKeys: []string{"query"}}))
+
+	logger.Info("msg", slog.String("db_query", "SELECT  *  FROM t WHERE id = $1"), slog.String("other", "SELECT * FROM t"))
+
+	expected := "[]  INFO  msg db_query=SELECT  *  FROM t WHERE id = $1 other=SELECT * FROM t\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testHighlightSQL(t *testing.T) {
+	h := NewHandler(&MockWriter{}, nil)
+
+	got := h.appendSQL(nil, "select a from t where b = 'x' and c = ? and d = :d::int and e > 10 -- note", "", false)
+	expected := "\x1b[35mselect\x1b[0m a \x1b[35mfrom\x1b[0m t \x1b[35mwhere\x1b[0m b = \x1b[32m'x'\x1b[0m " +
+		"\x1b[35mand\x1b[0m c = \x1b[33m?\x1b[0m \x1b[35mand\x1b[0m d = \x1b[33m:d\x1b[0m::int " +
+		"\x1b[35mand\x1b[0m e > \x1b[36m10\x1b[0m \x1b[2m-- note\x1b[0m"
+
+	if string(got) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, got)
+	}
+}
+
+func testFormatSQL(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, HighlightSQL: true, FormatSQL: true}))
+
+	logger.Info("msg", slog.String("q", "SELECT u.id FROM users u\n  LEFT JOIN orders o ON o.user_id = u.id WHERE u.age > 18 AND u.id IN (SELECT id FROM t) ORDER BY u.id"))
+
+	expected := "[]  INFO  msgQ q=\n" +
+		"  SELECT u.id\n" +
+		"  FROM users u\n" +
+		"  LEFT JOIN orders o\n" +
+		"    ON o.user_id = u.id\n" +
+		"  WHERE u.age > 18\n" +
+		"    AND u.id IN (SELECT id\n" +
+		"    FROM t)\n" +
+		"  ORDER BY u.id\n" +
+		"\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testNullValues(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true}))
+
+	logger.Info("msg", "name", sql.NullString{String: "bob", Valid: true}, "email", sql.NullString{})
+
+	expected := "[]  INFO  msg name=bob email=NULL\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testNullValuesInStruct(t *testing.T) {
+	type user struct {
+		Age   sql.NullInt64
+		Admin *sql.NullBool
+	}
+
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, EscapeNewlines: true}))
+
+	logger.Info("msg", "user", user{Admin: &sql.NullBool{Bool: true, Valid: true}})
+
+	expected := "[]  INFO  msg user=humanslog.user{Age=NULL Admin=true}\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testNullValuesLogfmt(t *testing.T) {
+	w := &MockWriter{}
+	h := NewHandler(w, &Options{Format: FormatLogfmt})
+
+	r := slog.Record{Level: slog.LevelInfo, Message: "msg"}
+	r.AddAttrs(slog.Any("age", sql.NullInt64{Int64: 42, Valid: true}), slog.Any("email", sql.NullString{}))
+	_ = h.Handle(context.Background(), r)
+
+	expected := "level=INFO msg=msg age=42 email=NULL\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
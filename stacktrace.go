@@ -6,7 +6,7 @@ import (
 	"runtime"
 )
 
-func (h *developHandler) getFileLineFromPC(pcs []uintptr) (fileLines []string) {
+func (h *Handler) getFileLineFromPC(pcs []uintptr) (fileLines []string) {
 	if len(pcs) == 0 {
 		return nil
 	}
@@ -27,7 +27,7 @@ func (h *developHandler) getFileLineFromPC(pcs []uintptr) (fileLines []string) {
 // - github.com/pkg/errors
 // - golang.org/x/xerrors
 // - golang.org/x/exp/errors
-func (h *developHandler) extractPCFromError(err error) (pc []uintptr) {
+func (h *Handler) extractPCFromError(err error) (pc []uintptr) {
 	if h.opts.MaxErrorStackTrace == 0 {
 		return nil
 	}
@@ -46,7 +46,7 @@ func (h *developHandler) extractPCFromError(err error) (pc []uintptr) {
 	return pc
 }
 
-func (h *developHandler) extractPCFromPkgErrors(v reflect.Value) (pc []uintptr) {
+func (h *Handler) extractPCFromPkgErrors(v reflect.Value) (pc []uintptr) {
 	// https://github.com/pkg/errors/blob/master/stack.go#L155
 	//
 	// type stackTracer interface {
@@ -78,7 +78,7 @@ func (h *developHandler) extractPCFromPkgErrors(v reflect.Value) (pc []uintptr)
 	return pc
 }
 
-func (h *developHandler) extractPCFromExpErrors(v reflect.Value) (pc []uintptr) {
+func (h *Handler) extractPCFromExpErrors(v reflect.Value) (pc []uintptr) {
 	// https://cs.opensource.google/go/x/exp/+/92128663:errors/fmt/errors.go;l=24
 	// https://cs.opensource.google/go/x/exp/+/92128663:errors/errors.go;l=25
 	//
@@ -0,0 +1,119 @@
+package humanslog
+
+import "log/slog"
+
+// Emphasis is a text attribute of a Style, emphases are combined with |, e.g. humanslog.Bold | humanslog.Italic
+type Emphasis uint8
+
+const (
+	Bold Emphasis = 1 << iota
+	Italic
+	Underline
+	Faint
+	Reverse
+)
+
+// recordStyleKey is the key of the attribute returned by RecordStyle
+const recordStyleKey = "humanslog.style"
+
+// Style is a text color with a background and emphasis, UnknownColor keeps the default
+type Style struct {
+	Color      Color
+	Background Color
+	Emphasis   Emphasis
+}
+
+// RecordStyle returns an attribute overriding the message color and emphasis of its record,
+// e.g. logger.Info("server ready", humanslog.RecordStyle(humanslog.Magenta, humanslog.Bold))
+func RecordStyle(c Color, emphasis ...Emphasis) slog.Attr {
+	s := Style{Color: c}
+	for _, e := range emphasis {
+		s.Emphasis |= e
+	}
+
+	return slog.Any(recordStyleKey, s)
+}
+
+// styleOf reports the style of the attribute, if it was returned by RecordStyle
+func styleOf(a slog.Attr) (Style, bool) {
+	if a.Key != recordStyleKey || a.Value.Kind() != slog.KindAny {
+		return Style{}, false
+	}
+
+	s, ok := a.Value.Any().(Style)
+	return s, ok
+}
+
+// Styles of output elements, a style without a color keeps the color of Colors or the default,
+// e.g. Styles{Key: Style{Emphasis: Italic}, ErrorMessage: Style{Color: Red, Emphasis: Bold}}
+type Styles struct {
+	Key    Style
+	Number Style
+	String Style
+	URL    Style
+	Type   Style
+	Punct  Style
+	Time   Style
+	Source Style
+
+	// Messages of records by level, RecordStyle takes precedence
+	DebugMessage Style
+	InfoMessage  Style
+	WarnMessage  Style
+	ErrorMessage Style
+}
+
+// messageStyle returns the style of messages at level, it reports false when it's not set
+func (h *Handler) messageStyle(level slog.Level) (Style, bool) {
+	var s Style
+	switch {
+	case level < slog.LevelInfo:
+		s = h.opts.Styles.DebugMessage
+	case level < slog.LevelWarn:
+		s = h.opts.Styles.InfoMessage
+	case level < slog.LevelError:
+		s = h.opts.Styles.WarnMessage
+	default:
+		s = h.opts.Styles.ErrorMessage
+	}
+
+	return s, s != Style{}
+}
+
+// styledText wraps b in the escape sequences of the style
+func (h *Handler) styledText(b []byte, s Style) []byte {
+	if h.opts.NoColor {
+		return b
+	}
+
+	out := h.styleCode(make([]byte, 0, len(b)+16), s)
+	out = append(out, b...)
+	return append(out, resetColor...)
+}
+
+// styleCode appends the escape sequences starting the style
+func (h *Handler) styleCode(b []byte, s Style) []byte {
+	if s.Emphasis&Bold != 0 {
+		b = append(b, "\x1b[1m"...)
+	}
+	if s.Emphasis&Italic != 0 {
+		b = append(b, "\x1b[3m"...)
+	}
+	if s.Emphasis&Underline != 0 {
+		b = append(b, underlineColor...)
+	}
+	if s.Emphasis&Faint != 0 {
+		b = append(b, faintColor...)
+	}
+	if s.Emphasis&Reverse != 0 {
+		b = append(b, "\x1b[7m"...)
+	}
+	if s.Background != UnknownColor {
+		b = append(b, h.getColor(s.Background).bg...)
+	}
+	if s.Color != UnknownColor {
+		b = append(b, h.getColor(s.Color).fg...)
+	}
+
+	return b
+}
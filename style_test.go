@@ -0,0 +1,37 @@
+package humanslog
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func Test_Style(t *testing.T) {
+	testRecordStyle(t)
+	testRecordStyleNoColor(t)
+}
+
+func testRecordStyle(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]"}))
+
+	logger.Info("server ready", RecordStyle(Magenta, Bold, Underline), slog.Int("port", 80))
+
+	expected := "\x1b[2m[]\x1b[0m \x1b[42m\x1b[30m INFO \x1b[0m \x1b[1m\x1b[4m\x1b[35mserver ready\x1b[0m \x1b[90mport=\x1b[0m\x1b[36m80\x1b[0m\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testRecordStyleNoColor(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true}))
+
+	logger.Info("migration complete", RecordStyle(Green, Bold))
+
+	expected := "[]  INFO  migration complete\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
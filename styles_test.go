@@ -0,0 +1,68 @@
+package humanslog
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+func Test_Styles(t *testing.T) {
+	testStylesMessageByLevel(t)
+	testStylesKey(t)
+	testStylesRecordStyleWins(t)
+	testBadgeEmphasis(t)
+}
+
+func testStylesMessageByLevel(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", Styles: Styles{ErrorMessage: Style{Color: Red, Emphasis: Bold}}}))
+
+	logger.Error("failed")
+	logger.Info("ok")
+
+	expected := "\x1b[2m[]\x1b[0m \x1b[41m\x1b[30m ERROR \x1b[0m \x1b[1m\x1b[31mfailed\x1b[0m\n" +
+		"\x1b[2m[]\x1b[0m \x1b[42m\x1b[30m INFO \x1b[0m ok\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testStylesKey(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", Styles: Styles{Key: Style{Emphasis: Italic}, Number: Style{Emphasis: Reverse | Faint}}}))
+
+	logger.Info("msg", "n", 1)
+
+	expected := "\x1b[2m[]\x1b[0m \x1b[42m\x1b[30m INFO \x1b[0m msg \x1b[3m\x1b[90mn=\x1b[0m\x1b[2m\x1b[7m\x1b[36m1\x1b[0m\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testStylesRecordStyleWins(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", Styles: Styles{InfoMessage: Style{Emphasis: Bold}}}))
+
+	logger.Info("msg", RecordStyle(Magenta))
+
+	expected := "\x1b[2m[]\x1b[0m \x1b[42m\x1b[30m INFO \x1b[0m \x1b[35mmsg\x1b[0m\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testBadgeEmphasis(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", BadgeStyle: BadgeStyle{Emphasis: Bold}}))
+
+	logger.Info("msg")
+
+	expected := "\x1b[2m[]\x1b[0m \x1b[1m\x1b[42m\x1b[30m INFO \x1b[0m msg\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
@@ -0,0 +1,138 @@
+package humanslog
+
+import (
+	"bytes"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Rows after which the header row is repeated when Options.TableHeaderEvery is 0
+const defaultTableHeaderEvery = 20
+
+// tableLayout is the column layout of consecutive records sharing attribute keys
+type tableLayout struct {
+	mu     sync.Mutex
+	keys   []string
+	prefix int
+	widths []int
+	rows   int
+}
+
+type tableCell struct {
+	key   string
+	value []byte
+}
+
+// reset starts a new table with the next record
+func (t *tableLayout) reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.keys = nil
+}
+
+// tableCells returns the inline attributes as cells, groups are flattened with dot notation
+func (h *Handler) tableCells(cells []tableCell, as attributes, group []string) []tableCell {
+	for _, a := range as {
+		if h.opts.ReplaceAttr != nil {
+			a = h.opts.ReplaceAttr(group, a)
+		}
+
+		if a.Value.Kind() == slog.KindGroup && !h.inlineGroups() {
+			cells = h.tableCells(cells, a.Value.Group(), append(group, a.Key))
+			continue
+		}
+
+		key := a.Key
+		if len(group) > 0 {
+			key = strings.Join(group, ".") + "." + a.Key
+		}
+
+		cells = append(cells, tableCell{key: key, value: h.appendLogfmtValue(nil, a, group)})
+	}
+
+	return cells
+}
+
+// formatTableRow aligns the message and attribute values of the record at b[start:] with the previous records
+// sharing its attribute keys. A header row with the keys is written before the first row, after the widths grow
+// and every TableHeaderEvery rows. msgStart is the offset of the message in b.
+func (h *Handler) formatTableRow(b []byte, start, msgStart int, cells []tableCell) []byte {
+	t := h.table
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	keys := make([]string, len(cells))
+	for i, c := range cells {
+		keys[i] = c.key
+	}
+
+	header := !slices.Equal(keys, t.keys)
+	if header {
+		t.keys = keys
+		t.prefix = 0
+		t.widths = make([]int, len(cells)+1)
+		t.widths[0] = len("message")
+		for i, k := range keys {
+			t.widths[i+1] = visibleLen([]byte(k))
+		}
+	}
+
+	every := h.opts.TableHeaderEvery
+	if every == 0 {
+		every = defaultTableHeaderEvery
+	}
+	if every > 0 && t.rows >= every {
+		header = true
+	}
+
+	prefix := visibleLen(b[start:msgStart])
+	if prefix > t.prefix {
+		t.prefix = prefix
+		header = true
+	}
+
+	widths := make([]int, len(cells)+1)
+	widths[0] = visibleLen(b[msgStart:])
+	for i, c := range cells {
+		widths[i+1] = visibleLen(c.value)
+	}
+	for i, w := range widths {
+		if w > t.widths[i] {
+			t.widths[i] = w
+			header = true
+		}
+	}
+
+	// Level badges differ in width, so messages are aligned by padding before them
+	b = slices.Insert(b, msgStart, bytes.Repeat([]byte(" "), t.prefix-prefix)...)
+
+	for i, c := range cells {
+		b = append(b, bytes.Repeat([]byte(" "), t.widths[i]-widths[i]+2)...)
+		b = append(b, c.value...)
+	}
+
+	if header {
+		t.rows = 0
+		b = slices.Insert(b, start, h.tableHeader(t)...)
+	}
+	t.rows++
+
+	return b
+}
+
+func (h *Handler) tableHeader(t *tableLayout) []byte {
+	row := make([]byte, 0, 64)
+	row = append(row, bytes.Repeat([]byte(" "), t.prefix)...)
+	row = append(row, "message"...)
+	prev := len("message")
+	for i, k := range t.keys {
+		row = append(row, bytes.Repeat([]byte(" "), t.widths[i]-prev+2)...)
+		row = append(row, k...)
+		prev = visibleLen([]byte(k))
+	}
+
+	return append(h.faintedText(row), '\n')
+}
@@ -0,0 +1,69 @@
+package humanslog
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func Test_Table(t *testing.T) {
+	testTableRows(t)
+	testTableColumnsGrow(t)
+	testTableHeaderEvery(t)
+}
+
+func testTableRows(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, TableMode: true}))
+
+	logger.Info("request", "method", "GET", "status", 200)
+	logger.Warn("request", "method", "POST", "status", 500)
+	logger.Info("poll", "queue", "emails")
+	logger.Info("done")
+
+	expected := "          message  method  status\n" +
+		"[]  INFO  request  GET     200\n" +
+		"[]  WARN  request  POST    500\n" +
+		"          message  queue\n" +
+		"[]  INFO  poll     emails\n" +
+		"[]  INFO  done\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testTableColumnsGrow(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, TableMode: true}))
+
+	logger.Info("request", "path", "/")
+	logger.Error("request handled", "path", "/users/1")
+
+	expected := "          message  path\n" +
+		"[]  INFO  request  /\n" +
+		"           message          path\n" +
+		"[]  ERROR  request handled  /users/1\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testTableHeaderEvery(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, TableMode: true, TableHeaderEvery: 2}))
+
+	for i := 0; i < 3; i++ {
+		logger.Info("tick", "n", i)
+	}
+
+	expected := "          message  n\n" +
+		"[]  INFO  tick     0\n" +
+		"[]  INFO  tick     1\n" +
+		"          message  n\n" +
+		"[]  INFO  tick     2\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
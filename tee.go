@@ -0,0 +1,61 @@
+package humanslog
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// teeHandler forwards records to multiple handlers
+type teeHandler struct {
+	handlers []slog.Handler
+}
+
+// NewTeeHandler returns a handler forwarding every record to all handlers which are enabled for its level,
+// e.g. humanslog to stderr and slog.JSONHandler to a file
+func NewTeeHandler(handlers ...slog.Handler) slog.Handler {
+	return &teeHandler{handlers: handlers}
+}
+
+func (t *teeHandler) Enabled(ctx context.Context, l slog.Level) bool {
+	for _, h := range t.handlers {
+		if h.Enabled(ctx, l) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *teeHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, h := range t.handlers {
+		if !h.Enabled(ctx, r.Level) {
+			continue
+		}
+
+		if err := h.Handle(ctx, r.Clone()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (t *teeHandler) WithAttrs(as []slog.Attr) slog.Handler {
+	hs := make([]slog.Handler, len(t.handlers))
+	for i, h := range t.handlers {
+		hs[i] = h.WithAttrs(as)
+	}
+
+	return &teeHandler{handlers: hs}
+}
+
+func (t *teeHandler) WithGroup(name string) slog.Handler {
+	hs := make([]slog.Handler, len(t.handlers))
+	for i, h := range t.handlers {
+		hs[i] = h.WithGroup(name)
+	}
+
+	return &teeHandler{handlers: hs}
+}
@@ -0,0 +1,83 @@
+package humanslog
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func Test_Tee(t *testing.T) {
+	testTeeHandler(t)
+	testTeeHandlerLevels(t)
+	testTeeHandlerErrors(t)
+}
+
+func testTeeHandler(t *testing.T) {
+	human := &MockWriter{}
+	jsonOut := &MockWriter{}
+
+	logger := slog.New(NewTeeHandler(
+		NewHandler(human, &Options{TimeFormat: "[]", NoColor: true}),
+		slog.NewJSONHandler(jsonOut, &slog.HandlerOptions{ReplaceAttr: dropTime}),
+	))
+
+	logger.With("a", 1).WithGroup("g").Info("msg", "b", 2)
+
+	expectedHuman := "[]  INFO  msg g.b=2 a=1\n"
+	expectedJSON := `{"level":"INFO","msg":"msg","a":1,"g":{"b":2}}` + "\n"
+
+	if string(human.WrittenData) != expectedHuman {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expectedHuman, human.WrittenData)
+	}
+
+	if string(jsonOut.WrittenData) != expectedJSON {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expectedJSON, jsonOut.WrittenData)
+	}
+}
+
+func testTeeHandlerLevels(t *testing.T) {
+	debug := &MockWriter{}
+	warn := &MockWriter{}
+
+	h := NewTeeHandler(
+		NewHandler(debug, &Options{HandlerOptions: &slog.HandlerOptions{Level: slog.LevelDebug}, TimeFormat: "[]", NoColor: true}),
+		NewHandler(warn, &Options{HandlerOptions: &slog.HandlerOptions{Level: slog.LevelWarn}, TimeFormat: "[]", NoColor: true}),
+	)
+
+	if !h.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("Expected Debug to be enabled by one of the handlers")
+	}
+
+	slog.New(h).Debug("msg")
+
+	if len(debug.WrittenData) == 0 || len(warn.WrittenData) != 0 {
+		t.Errorf("Expected Debug record only in the debug handler, got %q and %q", debug.WrittenData, warn.WrittenData)
+	}
+}
+
+func testTeeHandlerErrors(t *testing.T) {
+	errWrite := errors.New("write failed")
+	h := NewTeeHandler(NewHandler(failingWriter{errWrite}, nil), NewHandler(&MockWriter{}, nil))
+
+	err := h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0))
+	if !errors.Is(err, errWrite) {
+		t.Errorf("Expected write error, got %v", err)
+	}
+}
+
+type failingWriter struct {
+	err error
+}
+
+func (w failingWriter) Write(p []byte) (int, error) {
+	return 0, w.err
+}
+
+func dropTime(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) == 0 && a.Key == slog.TimeKey {
+		return slog.Attr{}
+	}
+	return a
+}
@@ -0,0 +1,108 @@
+package humanslog
+
+import (
+	"log/slog"
+	"strings"
+)
+
+// interpolateMessage replaces {key} placeholders in msg with the values of record attributes and removes the substituted
+// attributes from as, text renders the parts of the message between placeholders
+func (h *Handler) interpolateMessage(msg string, as attributes, text func(string) []byte) ([]byte, attributes) {
+	groups := h.groups()
+	used := make(map[int]bool)
+
+	var b []byte
+	var literal strings.Builder
+	for {
+		i := strings.IndexByte(msg, '{')
+		if i < 0 {
+			break
+		}
+		j := strings.IndexByte(msg[i+1:], '}')
+		if j < 0 {
+			break
+		}
+
+		k, v := h.templateValue(as, msg[i+1:i+1+j], groups)
+		if k < 0 {
+			// Not an attribute, the brace is kept as text
+			literal.WriteString(msg[:i+1])
+			msg = msg[i+1:]
+			continue
+		}
+
+		literal.WriteString(msg[:i])
+		if literal.Len() > 0 {
+			b = append(b, text(literal.String())...)
+			literal.Reset()
+		}
+		b = append(b, v...)
+		used[k] = true
+		msg = msg[i+j+2:]
+	}
+	literal.WriteString(msg)
+	if literal.Len() > 0 || len(b) == 0 {
+		b = append(b, text(literal.String())...)
+	}
+
+	if len(used) == 0 {
+		return b, as
+	}
+
+	rest := make(attributes, 0, len(as)-len(used))
+	for i, a := range as {
+		if !used[i] {
+			rest = append(rest, a)
+		}
+	}
+
+	return b, rest
+}
+
+// templateValue returns the index and rendered value of the attribute keyed key, or -1 when there is none or its value
+// doesn't fit in a sentence. Hidden keys, secrets and redactions apply as they do to the attribute list.
+func (h *Handler) templateValue(as attributes, key string, groups []string) (int, []byte) {
+	if key == "" {
+		return -1, nil
+	}
+
+	for i, a := range as {
+		if a.Key != key {
+			continue
+		}
+
+		filtered := h.truncateStrings(h.redactAttrs(h.maskSecrets(resolveValuers(h.filterKeys(attributes{a}, groups)), groups)))
+		if len(filtered) == 0 {
+			return -1, nil
+		}
+		a = filtered[0]
+
+		if h.opts.ReplaceAttr != nil {
+			a = h.opts.ReplaceAttr(groups, a)
+			if a.Key == "" {
+				return -1, nil
+			}
+		}
+
+		if a.Value.Kind() == slog.KindGroup || h.attrContainsNewline(a) || h.isJSONValue(a.Value) || h.isMultilineJSON(a.Value) || h.isXMLValue(a.Value) ||
+			h.attrContainsStruct(a) || h.attrIsErrorTree(a) {
+			return -1, nil
+		}
+
+		return i, h.appendLogfmtValue(nil, a, groups)
+	}
+
+	return -1, nil
+}
+
+// groups returns the names of the groups opened with WithGroup
+func (h *Handler) groups() []string {
+	var groups []string
+	for _, goa := range h.goas {
+		if goa.group != "" {
+			groups = append(groups, goa.group)
+		}
+	}
+
+	return groups
+}
@@ -0,0 +1,80 @@
+package humanslog
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+func Test_MessageTemplates(t *testing.T) {
+	testMessageTemplate(t)
+	testMessageTemplateUnknownKey(t)
+	testMessageTemplateColors(t)
+	testMessageTemplateSecret(t)
+	testMessageTemplateDisabled(t)
+}
+
+func testMessageTemplate(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, MessageTemplates: true}))
+
+	logger.Info("user {user_id} logged in", "user_id", 42, "ip", "10.0.0.1")
+
+	expected := "[]  INFO  user 42 logged in ip=10.0.0.1\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testMessageTemplateUnknownKey(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, MessageTemplates: true}))
+
+	logger.Info("{missing} {} {a}", "a", "x", "g", slog.GroupValue(slog.Int("b", 1)))
+
+	expected := "[]  INFO  {missing} {} x g.b=1\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testMessageTemplateColors(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", MessageTemplates: true}))
+
+	logger.Info("took {n}", "n", 3)
+
+	expected := "\x1b[2m[]\x1b[0m \x1b[42m\x1b[30m INFO \x1b[0m took \x1b[36m3\x1b[0m\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testMessageTemplateSecret(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, MessageTemplates: true, HideKeys: []string{"token"}}))
+
+	logger.Info("token {token}", "token", "abc")
+
+	expected := "[]  INFO  token {token}\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testMessageTemplateDisabled(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true}))
+
+	logger.Info("user {user_id}", "user_id", 42)
+
+	expected := "[]  INFO  user {user_id} user_id=42\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
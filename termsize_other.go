@@ -0,0 +1,10 @@
+//go:build !linux && !darwin && !freebsd && !openbsd && !netbsd && !windows
+
+package humanslog
+
+import "os"
+
+// fileTerminalWidth returns 0, the terminal size can't be detected on this platform
+func fileTerminalWidth(f *os.File) int {
+	return 0
+}
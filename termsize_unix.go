@@ -0,0 +1,27 @@
+//go:build linux || darwin || freebsd || openbsd || netbsd
+
+package humanslog
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+type winsize struct {
+	rows    uint16
+	cols    uint16
+	xpixels uint16
+	ypixels uint16
+}
+
+// fileTerminalWidth returns the number of columns of the terminal, 0 when f isn't a terminal
+func fileTerminalWidth(f *os.File) int {
+	var ws winsize
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&ws)))
+	if errno != 0 {
+		return 0
+	}
+
+	return int(ws.cols)
+}
@@ -0,0 +1,44 @@
+package humanslog
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// textAppender is encoding.TextAppender, added in Go 1.24
+type textAppender interface {
+	AppendText(b []byte) ([]byte, error)
+}
+
+// isTextAppender reports whether v implements encoding.TextAppender or fmt.Formatter and isn't a nil pointer
+func isTextAppender(v any) bool {
+	switch v.(type) {
+	case textAppender, fmt.Formatter:
+	default:
+		return false
+	}
+
+	rv := reflect.ValueOf(v)
+	return rv.Kind() != reflect.Pointer || !rv.IsNil()
+}
+
+// appendText renders values implementing encoding.TextAppender or fmt.Formatter, it reports false for other values.
+// A panic is rendered instead of crashing the application.
+func (h *Handler) appendText(v any) (b []byte, ok bool) {
+	if !isTextAppender(v) {
+		return nil, false
+	}
+
+	ok = true
+	defer h.recoverFormatter(&b)
+
+	if ta, isAppender := v.(textAppender); isAppender {
+		t, err := ta.AppendText(nil)
+		if err != nil {
+			return []byte("!ERROR:" + err.Error()), true
+		}
+		return t, true
+	}
+
+	return fmt.Appendf(nil, "%v", v), true
+}
@@ -0,0 +1,67 @@
+package humanslog
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+)
+
+type testVersion struct{ major, minor int }
+
+func (v testVersion) AppendText(b []byte) ([]byte, error) {
+	if v.major < 0 {
+		return nil, errors.New("negative version")
+	}
+
+	return fmt.Appendf(b, "v%d.%d", v.major, v.minor), nil
+}
+
+type testPoint struct{ x, y int }
+
+func (p testPoint) Format(f fmt.State, verb rune) {
+	fmt.Fprintf(f, "(%d,%d)", p.x, p.y)
+}
+
+func Test_TextAppender(t *testing.T) {
+	testTextAppenderInline(t)
+	testTextAppenderNested(t)
+	testTextAppenderNilPointer(t)
+}
+
+func testTextAppenderInline(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true}))
+
+	logger.Info("msg", "v", testVersion{1, 2}, "bad", testVersion{-1, 0}, "p", &testPoint{1, 2})
+
+	expected := "[]  INFO  msg v=v1.2 bad=!ERROR:negative version p=(1,2)\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testTextAppenderNested(t *testing.T) {
+	type release struct {
+		Version testVersion
+		Origin  testPoint
+	}
+
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, EscapeNewlines: true}))
+
+	logger.Info("msg", "r", release{Version: testVersion{2, 0}, Origin: testPoint{3, 4}})
+
+	expected := "[]  INFO  msg r=humanslog.release{Version=v2.0 Origin=(3,4)}\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testTextAppenderNilPointer(t *testing.T) {
+	if isTextAppender((*testVersion)(nil)) {
+		t.Error("nil pointers must not be rendered with AppendText")
+	}
+}
@@ -0,0 +1,21 @@
+package humanslog
+
+import "time"
+
+// recordTime returns the time of a record taken from TimeFunction and converted to TimeLocation,
+// records without time are left without it
+func (h *Handler) recordTime(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+
+	if h.opts.TimeFunction != nil {
+		t = h.opts.TimeFunction()
+	}
+
+	if h.opts.TimeLocation != nil {
+		t = t.In(h.opts.TimeLocation)
+	}
+
+	return t
+}
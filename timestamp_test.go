@@ -0,0 +1,50 @@
+package humanslog
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func Test_Timestamp(t *testing.T) {
+	testTimeFunction(t)
+	testTimeLocation(t)
+}
+
+func testTimeFunction(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{
+		NoColor:      true,
+		TimeFormat:   "2006-01-02 15:04:05",
+		TimeLocation: time.UTC,
+		TimeFunction: func() time.Time {
+			return time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+		},
+	}))
+
+	logger.Info("msg")
+
+	expected := "2024-05-01 12:30:00  INFO  msg\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testTimeLocation(t *testing.T) {
+	w := &MockWriter{}
+	h := NewHandler(w, &Options{NoColor: true, TimeFormat: "15:04 MST", TimeLocation: time.FixedZone("CET", 3600)})
+
+	r := slog.NewRecord(time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC), slog.LevelInfo, "msg", 0)
+	if err := h.Handle(context.Background(), r); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "13:30 CET  INFO  msg\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
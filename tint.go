@@ -0,0 +1,49 @@
+package humanslog
+
+import (
+	"bytes"
+	"log/slog"
+)
+
+// levelTint returns the color tinting whole lines of records of the level, nil for Info
+func (h *Handler) levelTint(l slog.Level) []byte {
+	switch {
+	case l < slog.LevelInfo:
+		return faintColor
+	case l < slog.LevelWarn:
+		return nil
+	case l < slog.LevelError:
+		return h.getColor(h.opts.WarnColor).fg
+	default:
+		return h.getColor(h.opts.ErrorColor).fg
+	}
+}
+
+// tintLines applies the level tint to every line of b, elements with their own color keep it
+func (h *Handler) tintLines(b []byte, l slog.Level) []byte {
+	tint := h.levelTint(l)
+	if !h.opts.TintLines || h.opts.NoColor || tint == nil {
+		return b
+	}
+
+	return recolorLines(b, tint)
+}
+
+// recolorLines starts every line of b with code and restores it after resets inside the line
+func recolorLines(b []byte, tint []byte) []byte {
+	reset := []byte(resetColor)
+	retint := append(append([]byte(nil), resetColor...), tint...)
+
+	out := make([]byte, 0, len(b)+64)
+	for _, line := range bytes.SplitAfter(b, []byte("\n")) {
+		content := bytes.TrimSuffix(line, []byte("\n"))
+		if len(content) > 0 {
+			out = append(out, tint...)
+			out = append(out, bytes.ReplaceAll(content, reset, retint)...)
+			out = append(out, resetColor...)
+		}
+		out = append(out, line[len(content):]...)
+	}
+
+	return out
+}
@@ -0,0 +1,52 @@
+package humanslog
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func Test_Tint(t *testing.T) {
+	testTintLines(t)
+	testTintLinesInfo(t)
+	testTintLinesNoColor(t)
+}
+
+func testTintLines(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", TintLines: true}))
+
+	logger.Error("failed", slog.Int("n", 1), slog.String("s", "a\nb"))
+
+	expected := "\x1b[31m\x1b[2m[]\x1b[0m\x1b[31m \x1b[41m\x1b[30m ERROR \x1b[0m\x1b[31m failed \x1b[90mn=\x1b[0m\x1b[31m\x1b[36m1\x1b[0m\x1b[31m \x1b[90ms\x1b[0m\x1b[31m=a\x1b[0m\n" +
+		"\x1b[31mb\x1b[0m\n\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testTintLinesInfo(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", TintLines: true}))
+
+	logger.Info("msg")
+
+	expected := "\x1b[2m[]\x1b[0m \x1b[42m\x1b[30m INFO \x1b[0m msg\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testTintLinesNoColor(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", TintLines: true, NoColor: true}))
+
+	logger.Warn("msg")
+
+	expected := "[]  WARN  msg\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
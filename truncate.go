@@ -0,0 +1,90 @@
+package humanslog
+
+import (
+	"log/slog"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// fullString is a string value exempt from MaxStringLength
+type fullString string
+
+// Full returns a value rendered without the truncation of MaxStringLength, e.g. logger.Info("msg", "body", humanslog.Full(body)).
+// Other handlers get the plain value.
+func Full(v any) slog.Value {
+	if s, ok := v.(string); ok {
+		return slog.AnyValue(fullString(s))
+	}
+
+	return slog.AnyValue(v)
+}
+
+// truncateStrings cuts string values longer than MaxStringLength bytes, values of Full and masks of redaction are left whole
+func (h *Handler) truncateStrings(as attributes) attributes {
+	if h.opts.MaxStringLength <= 0 {
+		return as
+	}
+
+	truncated := make(attributes, len(as))
+	for i, a := range as {
+		switch a.Value.Kind() {
+		case slog.KindGroup:
+			a.Value = slog.GroupValue(h.truncateStrings(a.Value.Group())...)
+		case slog.KindString:
+			a.Value = slog.StringValue(h.truncateString(a.Value.String()))
+		case slog.KindAny:
+			if s, ok := a.Value.Any().(fullString); ok {
+				a.Value = slog.StringValue(string(s))
+			}
+		}
+
+		truncated[i] = a
+	}
+
+	return truncated
+}
+
+// truncateString cuts s at a rune boundary within MaxStringLength bytes, marking the number of bytes left out
+func (h *Handler) truncateString(s string) string {
+	if h.opts.MaxStringLength <= 0 || len(s) <= h.opts.MaxStringLength {
+		return s
+	}
+
+	n := h.maskEnd(s, h.opts.MaxStringLength)
+	if n >= len(s) {
+		return s
+	}
+
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+
+	return s[:n] + h.glyphs("…") + "(+" + strconv.Itoa(len(s)-n) + " bytes)"
+}
+
+// maskEnd moves the cut at n behind a placeholder of Redact or MaskSecrets it would split, masks are kept whole
+func (h *Handler) maskEnd(s string, n int) int {
+	for _, p := range []string{h.redactReplacement(), "[REDACTED "} {
+		for i := 0; i < n; {
+			j := strings.Index(s[i:], p)
+			if j < 0 || i+j >= n {
+				break
+			}
+			end := i + j + len(p)
+			if p == "[REDACTED " {
+				// "[REDACTED kind]" of MaskSecrets
+				if k := strings.IndexByte(s[end:], ']'); k >= 0 {
+					end += k + 1
+				}
+			}
+
+			if n < end {
+				return end
+			}
+			i = end
+		}
+	}
+
+	return n
+}
@@ -0,0 +1,59 @@
+package humanslog
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+)
+
+// isTerminal reports whether w is a file connected to a terminal
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// NewAutoHandler returns the humanslog handler when out is a terminal and fallback otherwise,
+// e.g. slog.NewJSONHandler(out, nil) for CI logs. Nil fallback disables colors instead.
+// FORCE_COLOR or CLICOLOR_FORCE select the humanslog handler with colors also when out isn't a terminal.
+func NewAutoHandler(out io.Writer, o *Options, fallback slog.Handler) slog.Handler {
+	opts := Options{}
+	if o != nil {
+		opts = *o
+	}
+
+	if isTerminal(out) || opts.colorEnvironment() == colorEnvForce {
+		return NewHandler(out, o)
+	}
+
+	if fallback != nil {
+		return fallback
+	}
+
+	opts.AutoDetectTTY = true
+
+	return NewHandler(out, &opts)
+}
+
+// terminalWidth returns the number of columns of the terminal w writes to, falling back to $COLUMNS, 0 when unknown
+func terminalWidth(w io.Writer) int {
+	if !isTerminal(w) {
+		return 0
+	}
+
+	if n := fileTerminalWidth(w.(*os.File)); n > 0 {
+		return n
+	}
+
+	n, _ := strconv.Atoi(os.Getenv("COLUMNS"))
+	return max(n, 0)
+}
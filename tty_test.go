@@ -0,0 +1,71 @@
+package humanslog
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_TTY(t *testing.T) {
+	testIsTerminal(t)
+	testAutoDetectTTY(t)
+	testNewAutoHandlerFallback(t)
+	testNewAutoHandlerNoColor(t)
+}
+
+func testIsTerminal(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if isTerminal(f) {
+		t.Error("Expected regular file not to be a terminal")
+	}
+
+	if isTerminal(&MockWriter{}) {
+		t.Error("Expected non-file writer not to be a terminal")
+	}
+}
+
+func testAutoDetectTTY(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", AutoDetectTTY: true}))
+
+	logger.Info("msg", slog.Int("n", 1))
+
+	expected := "[]  INFO  msg n=1\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testNewAutoHandlerFallback(t *testing.T) {
+	w := &MockWriter{}
+	fallback := slog.NewJSONHandler(w, nil)
+
+	if h := NewAutoHandler(w, nil, fallback); h != fallback {
+		t.Errorf("Expected fallback handler, got %T", h)
+	}
+}
+
+func testNewAutoHandlerNoColor(t *testing.T) {
+	w := &MockWriter{}
+	opts := &Options{TimeFormat: "[]"}
+	logger := slog.New(NewAutoHandler(w, opts, nil))
+
+	logger.Info("msg")
+
+	expected := "[]  INFO  msg\n"
+
+	if string(w.WrittenData) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+
+	if opts.NoColor {
+		t.Error("Expected passed options not to be modified")
+	}
+}
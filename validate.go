@@ -0,0 +1,175 @@
+package humanslog
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+)
+
+// Validate reports options NewHandler would silently coerce or ignore, e.g. negative sizes and unknown colors
+func (o *Options) Validate() error {
+	if o == nil {
+		return nil
+	}
+
+	var errs []error
+	invalid := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("humanslog: "+format, args...))
+	}
+
+	nonNegative := []struct {
+		name  string
+		value int
+	}{
+		{"BufferSize", o.BufferSize},
+		{"HTTPBodySize", o.HTTPBodySize},
+		{"InlineGroupMaxAttrs", o.InlineGroupMaxAttrs},
+		{"InlineGroupMaxWidth", o.InlineGroupMaxWidth},
+		{"CollapseGroupsOver", o.CollapseGroupsOver},
+		{"MessagePadding", o.MessagePadding},
+		{"TabWidth", o.TabWidth},
+		{"JSONFoldSize", o.JSONFoldSize},
+		{"JSONInlineSize", o.JSONInlineSize},
+		{"SourceSnippetLines", o.SourceSnippetLines},
+		{"MaxSpanDepth", o.MaxSpanDepth},
+		{"MaxAttrs", o.MaxAttrs},
+		{"RecentRecords", o.RecentRecords},
+		{"CallerSkip", o.CallerSkip},
+		{"AsyncQueueSize", o.AsyncQueueSize},
+		{"MaxXMLSize", o.MaxXMLSize},
+		{"MaxDepth", o.MaxDepth},
+		{"MaxValueBytes", o.MaxValueBytes},
+		{"MaxStringLength", o.MaxStringLength},
+		{"ErrorSeenSize", o.ErrorSeenSize},
+		{"HexDumpOver", o.HexDumpOver},
+		{"HexDumpRows", o.HexDumpRows},
+		{"BadgeStyle.Width", o.BadgeStyle.Width},
+	}
+	for _, f := range nonNegative {
+		if f.value < 0 {
+			invalid("%s must not be negative, got %d", f.name, f.value)
+		}
+	}
+
+	if o.WrapWidth < NoWrap {
+		invalid("WrapWidth must not be negative other than NoWrap, got %d", o.WrapWidth)
+	}
+
+	if o.DedupWindow < 0 {
+		invalid("DedupWindow must not be negative, got %s", o.DedupWindow)
+	}
+
+	if o.ErrorSeenWindow < 0 {
+		invalid("ErrorSeenWindow must not be negative, got %s", o.ErrorSeenWindow)
+	}
+
+	levels := make([]slog.Level, 0, len(o.Sampling))
+	for l := range o.Sampling {
+		levels = append(levels, l)
+	}
+	slices.Sort(levels)
+	for _, l := range levels {
+		if burst := o.Sampling[l].Burst; burst < 0 {
+			invalid("Sampling[%s].Burst must not be negative, got %d", l, burst)
+		}
+	}
+
+	type namedColor struct {
+		name  string
+		value Color
+	}
+	colors := []namedColor{
+		{"DebugColor", o.DebugColor},
+		{"InfoColor", o.InfoColor},
+		{"WarnColor", o.WarnColor},
+		{"ErrorColor", o.ErrorColor},
+		{"Colors.Key", o.Colors.Key},
+		{"Colors.Number", o.Colors.Number},
+		{"Colors.String", o.Colors.String},
+		{"Colors.URL", o.Colors.URL},
+		{"Colors.Type", o.Colors.Type},
+		{"Colors.Punct", o.Colors.Punct},
+		{"Colors.Time", o.Colors.Time},
+		{"Colors.Source", o.Colors.Source},
+	}
+	styles := []struct {
+		name  string
+		value Style
+	}{
+		{"Key", o.Styles.Key}, {"Number", o.Styles.Number}, {"String", o.Styles.String}, {"URL", o.Styles.URL},
+		{"Type", o.Styles.Type}, {"Punct", o.Styles.Punct}, {"Time", o.Styles.Time}, {"Source", o.Styles.Source},
+		{"DebugMessage", o.Styles.DebugMessage}, {"InfoMessage", o.Styles.InfoMessage},
+		{"WarnMessage", o.Styles.WarnMessage}, {"ErrorMessage", o.Styles.ErrorMessage},
+	}
+	for _, s := range styles {
+		colors = append(colors,
+			namedColor{"Styles." + s.name + ".Color", s.value.Color},
+			namedColor{"Styles." + s.name + ".Background", s.value.Background},
+		)
+	}
+	for _, l := range o.CustomLevels {
+		colors = append(colors, namedColor{"CustomLevels " + l.Name + " Color", l.Color})
+	}
+	for i, r := range o.Highlights {
+		if r.Pattern == nil {
+			invalid("Highlights[%d] has no Pattern", i)
+		}
+		colors = append(colors,
+			namedColor{fmt.Sprintf("Highlights[%d] Style.Color", i), r.Style.Color},
+			namedColor{fmt.Sprintf("Highlights[%d] Style.Background", i), r.Style.Background},
+		)
+	}
+	for _, c := range colors {
+		if !validColor(c.value) {
+			invalid("%s is not a known color: %d", c.name, c.value)
+		}
+	}
+
+	if o.Format > FormatLogfmt {
+		invalid("unknown Format %d", o.Format)
+	}
+
+	if o.SortMode > SortPriority {
+		invalid("unknown SortMode %d", o.SortMode)
+	}
+
+	if o.BytesFormat > BytesBase64 {
+		invalid("unknown BytesFormat %d", o.BytesFormat)
+	}
+
+	if o.QuoteValues > QuoteAlways {
+		invalid("unknown QuoteValues %d", o.QuoteValues)
+	}
+
+	if o.SourcePath > SourcePathShort {
+		invalid("unknown SourcePath %d", o.SourcePath)
+	}
+
+	return errors.Join(errs...)
+}
+
+// validColor reports whether c is UnknownColor, meaning the default, or one of the colors
+func validColor(c Color) bool {
+	return int(c) < len(colors)
+}
+
+// NewHandlerE is NewHandler returning an error for invalid options, a nil writer
+// and a ReplayFile which can't be opened instead of ignoring them
+func NewHandlerE(out io.Writer, o *Options) (*Handler, error) {
+	if out == nil {
+		return nil, errors.New("humanslog: nil writer")
+	}
+
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+
+	h := NewHandler(out, o)
+	if f, ok := h.replay.(failedHandler); ok {
+		return nil, errors.Join(f.err, h.Close())
+	}
+
+	return h, nil
+}
@@ -0,0 +1,80 @@
+package humanslog
+
+import (
+	"log/slog"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func Test_Validate(t *testing.T) {
+	testValidateValid(t)
+	testValidateInvalid(t)
+	testNewHandlerE(t)
+}
+
+func testValidateValid(t *testing.T) {
+	var nilOptions *Options
+	if err := nilOptions.Validate(); err != nil {
+		t.Errorf("Expected nil options to be valid, got %v", err)
+	}
+
+	o := &Options{
+		WrapWidth:        NoWrap,
+		TableHeaderEvery: -1,
+		InfoColor:        Cyan,
+		Highlights:       []HighlightRule{{Pattern: regexp.MustCompile(`x`), Style: Style{Background: Yellow}}},
+	}
+	if err := o.Validate(); err != nil {
+		t.Errorf("Expected options to be valid, got %v", err)
+	}
+}
+
+func testValidateInvalid(t *testing.T) {
+	o := &Options{
+		MaxAttrs:   -1,
+		ErrorColor: Color(42),
+		Highlights: []HighlightRule{{}},
+		Format:     Format(9),
+		BadgeStyle: BadgeStyle{Width: -2},
+		Sampling:   map[slog.Level]SamplingRate{slog.LevelInfo: {Burst: -1}},
+	}
+
+	err := o.Validate()
+	if err == nil {
+		t.Fatal("Expected invalid options")
+	}
+
+	for _, expected := range []string{
+		"humanslog: MaxAttrs must not be negative, got -1",
+		"humanslog: ErrorColor is not a known color: 42",
+		"humanslog: Highlights[0] has no Pattern",
+		"humanslog: unknown Format 9",
+		"humanslog: BadgeStyle.Width must not be negative, got -2",
+		"humanslog: Sampling[INFO].Burst must not be negative, got -1",
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error %q in:\n%v", expected, err)
+		}
+	}
+}
+
+func testNewHandlerE(t *testing.T) {
+	if _, err := NewHandlerE(nil, nil); err == nil {
+		t.Error("Expected an error for nil writer")
+	}
+
+	if _, err := NewHandlerE(&MockWriter{}, &Options{BufferSize: -1}); err == nil {
+		t.Error("Expected an error for invalid options")
+	}
+
+	if _, err := NewHandlerE(&MockWriter{}, &Options{ReplayFile: filepath.Join(t.TempDir(), "missing", "replay.jsonl")}); err == nil {
+		t.Error("Expected an error for replay file which can't be opened")
+	}
+
+	h, err := NewHandlerE(&MockWriter{}, &Options{NoColor: true})
+	if err != nil || h == nil {
+		t.Errorf("Expected a handler, got %v", err)
+	}
+}
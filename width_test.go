@@ -0,0 +1,66 @@
+package humanslog
+
+import (
+	"bytes"
+	"log/slog"
+	"regexp"
+	"testing"
+	"unicode/utf8"
+)
+
+func Test_Width(t *testing.T) {
+	testDisplayWidth(t)
+	testStructPaddingWide(t)
+	testColoringKeepsRunes(t)
+}
+
+func testDisplayWidth(t *testing.T) {
+	cases := map[string]int{
+		"abc":  3,
+		"日本語":  6,
+		"✅ ok": 5,
+		"é":   1,
+		"👍🏽":   4,
+	}
+
+	for s, expected := range cases {
+		if w := displayWidth(s); w != expected {
+			t.Errorf("Expected display width of %q to be %d, got %d", s, expected, w)
+		}
+	}
+}
+
+func testStructPaddingWide(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true}))
+
+	type wide struct {
+		ＩＤ  string
+		Age int
+	}
+
+	logger.Info("msg", slog.Any("s", wide{ＩＤ: "x", Age: 1}))
+
+	expected := "[]  INFO  msgS s=humanslog.wide\n    ＩＤ: x\n    Age : 1\n\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testColoringKeepsRunes(t *testing.T) {
+	h := NewHandler(&MockWriter{}, &Options{})
+	escapes := regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+	for _, b := range [][]byte{
+		h.colorizeJSONBytes([]byte(`{"ключ": "日本 👍"}`), false, 0),
+		h.buildTypeString("[]*pkg.żółw"),
+	} {
+		for _, part := range escapes.Split(string(b), -1) {
+			if !utf8.ValidString(part) {
+				t.Errorf("Expected escape sequences between whole runes, got %q", b)
+				break
+			}
+		}
+	}
+}
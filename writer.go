@@ -0,0 +1,167 @@
+package humanslog
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// WriterLocker is a writer shared by several handlers. Handlers writing to it
+// hold its lock for the whole record, so lines from different handlers don't interleave.
+type WriterLocker interface {
+	io.Writer
+	sync.Locker
+}
+
+// writerLocker returns the lock shared with other handlers, or nil if the handler should use its own
+func writerLocker(out io.Writer, o Options) sync.Locker {
+	if o.WriterLock != nil {
+		return o.WriterLock
+	}
+
+	if wl, ok := out.(WriterLocker); ok {
+		return wl
+	}
+
+	return nil
+}
+
+// sharedWriter is the writer of a handler and handlers derived from it, replaced by SetOutput under the lock
+type sharedWriter struct {
+	w io.Writer
+
+	// writer passed to NewHandler or SetOutput, w without the buffer of BufferSize, colors are detected for it
+	dest io.Writer
+
+	// whether w is a terminal, records are rewritten in place only there
+	terminal atomic.Bool
+
+	// columns of the terminal w writes to, 0 when unknown
+	width atomic.Int64
+}
+
+type lockedWriter struct {
+	mu sync.Locker
+	w  io.Writer
+}
+
+// NewLockedWriter returns a writer that holds mu during each Write.
+// Pass it to other handlers (e.g. slog.JSONHandler) together with Options.WriterLock set to the same mu.
+func NewLockedWriter(w io.Writer, mu sync.Locker) io.Writer {
+	return &lockedWriter{mu: mu, w: w}
+}
+
+func (lw *lockedWriter) Write(p []byte) (int, error) {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+
+	return lw.w.Write(p)
+}
+
+// write hands the rendered record to the writer, the caller must hold the lock
+func (h *Handler) write(b []byte) error {
+	if len(b) == 0 {
+		return nil
+	}
+
+	_, err := h.out.w.Write(b)
+
+	return err
+}
+
+// Flush reports pending repeats of a deduplicated record and records suppressed by Sampling, waits for records queued
+// by the async mode and writes any buffered records to the underlying writer when the output is a *bufio.Writer
+func (h *Handler) Flush() error {
+	err := h.flushSuppressed()
+	if h.dedup != nil {
+		h.dedup.mu.Lock()
+		err = errors.Join(err, h.flushRepeated(h.dedup))
+		h.dedup.mu.Unlock()
+	}
+
+	if h.async != nil {
+		err = errors.Join(err, h.async.flush())
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	bw, ok := h.out.w.(*bufio.Writer)
+	if !ok {
+		return err
+	}
+
+	return errors.Join(err, bw.Flush())
+}
+
+// Close writes queued and buffered records and stops the async mode goroutine,
+// records handled after Close are written synchronously
+func (h *Handler) Close() error {
+	// before the async mode stops, so the summaries keep the order of records
+	err := h.flushSuppressed()
+	if h.async != nil {
+		err = errors.Join(err, h.async.close())
+	}
+
+	return errors.Join(err, h.Flush())
+}
+
+// SetOutput replaces the writer of the handler and all handlers derived from it, records buffered
+// for the previous writer are written first. Progress and repeated records are rewritten in place only when
+// the new writer is a terminal, WrapWidth 0 follows its width. Colors of h are detected again for the new writer, which changes its options,
+// so it must not be called while h is logging, handlers derived from h earlier keep their colors.
+func (h *Handler) SetOutput(w io.Writer) error {
+	if h.async != nil {
+		if err := h.async.flush(); err != nil {
+			return err
+		}
+	}
+
+	// same lock order as writing a record
+	if d := h.dedup; d != nil {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+	}
+	if p := h.progress; p != nil {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var err error
+	if bw, ok := h.out.w.(*bufio.Writer); ok {
+		err = bw.Flush()
+		bw.Reset(w)
+	} else {
+		h.out.w = w
+	}
+	h.out.dest = w
+
+	terminal := isTerminal(w)
+	h.out.terminal.Store(terminal)
+	h.out.width.Store(int64(terminalWidth(w)))
+
+	noColor := h.opts.NoColor
+	h.detectColors(w)
+	if h.opts.NoColor != noColor {
+		h.progress = nil
+		if !h.opts.NoColor {
+			h.progress = &progressDisplay{terminal: terminal}
+		}
+	}
+
+	if h.progress != nil {
+		h.progress.terminal = terminal
+		h.progress.lines = 0
+	}
+
+	if h.dedup != nil {
+		h.dedup.inPlace = !h.opts.NoColor && terminal
+	}
+
+	return err
+}
@@ -0,0 +1,126 @@
+package humanslog
+
+import (
+	"bytes"
+	"log/slog"
+	"sync"
+	"testing"
+)
+
+func Test_Writer(t *testing.T) {
+	testWriterLockOption(t)
+	testWriterLockerInterface(t)
+	testLockedWriter(t)
+	testStringWriter(t)
+	testBufferSize(t)
+}
+
+type countingLocker struct {
+	sync.Mutex
+	locks int
+}
+
+func (l *countingLocker) Lock() {
+	l.Mutex.Lock()
+	l.locks++
+}
+
+type mockWriterLocker struct {
+	MockWriter
+	countingLocker
+}
+
+func testWriterLockOption(t *testing.T) {
+	w := &MockWriter{}
+	l := &countingLocker{}
+
+	h1 := NewHandler(w, &Options{WriterLock: l})
+	h2 := NewHandler(w, &Options{WriterLock: l})
+
+	slog.New(h1).Info("first")
+	slog.New(h2.WithGroup("g")).Info("second", "a", 1)
+
+	if l.locks != 2 {
+		t.Errorf("Expected shared lock to be taken 2 times, got %d", l.locks)
+	}
+}
+
+func testWriterLockerInterface(t *testing.T) {
+	w := &mockWriterLocker{}
+
+	slog.New(NewHandler(w, nil)).Info("msg")
+
+	if w.locks != 1 {
+		t.Errorf("Expected writer lock to be taken 1 time, got %d", w.locks)
+	}
+
+	if len(w.WrittenData) == 0 {
+		t.Error("Expected data to be written")
+	}
+}
+
+func testLockedWriter(t *testing.T) {
+	w := &MockWriter{}
+	l := &countingLocker{}
+
+	lw := NewLockedWriter(w, l)
+	slog.New(slog.NewTextHandler(lw, nil)).Info("msg")
+
+	if l.locks != 1 {
+		t.Errorf("Expected lock to be taken 1 time, got %d", l.locks)
+	}
+
+	if !bytes.Contains(w.WrittenData, []byte("msg=msg")) {
+		t.Errorf("Expected text handler output, got %q", w.WrittenData)
+	}
+}
+
+// mockStringWriter keeps the strings it was given, which WriteString is allowed to do
+type mockStringWriter struct {
+	MockWriter
+	strings []string
+}
+
+func (w *mockStringWriter) WriteString(s string) (int, error) {
+	w.strings = append(w.strings, s)
+	return w.Write([]byte(s))
+}
+
+func testStringWriter(t *testing.T) {
+	w := &mockStringWriter{}
+
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true}))
+	logger.Info("first")
+	logger.Info("second")
+
+	if len(w.strings) != 0 {
+		t.Errorf("Expected pooled buffers not to be handed to WriteString, got %q", w.strings)
+	}
+
+	expected := "[]  INFO  first\n[]  INFO  second\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testBufferSize(t *testing.T) {
+	w := &MockWriter{}
+	h := NewHandler(w, &Options{TimeFormat: "[]", NoColor: true, BufferSize: 4096})
+
+	slog.New(h).Info("msg")
+
+	if len(w.WrittenData) != 0 {
+		t.Errorf("Expected output to be buffered, got %q", w.WrittenData)
+	}
+
+	if err := h.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "[]  INFO  msg\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
@@ -0,0 +1,284 @@
+package humanslog
+
+import (
+	"log/slog"
+	"strconv"
+	"strings"
+)
+
+type xmlTokenKind int
+
+const (
+	xmlText xmlTokenKind = iota
+	xmlOpen
+	xmlClose
+	xmlSelfClosing
+	// xmlDirective is a comment, CDATA section, doctype or processing instruction
+	xmlDirective
+)
+
+type xmlToken struct {
+	kind xmlTokenKind
+	name string
+	raw  string
+}
+
+// htmlVoidElements are HTML elements without a closing tag
+var htmlVoidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+}
+
+// tokenizeXML splits XML or HTML into tags and text, it fails on unterminated tags
+func tokenizeXML(s string) ([]xmlToken, bool) {
+	var tokens []xmlToken
+	for len(s) > 0 {
+		if s[0] != '<' {
+			end := strings.IndexByte(s, '<')
+			if end < 0 {
+				end = len(s)
+			}
+			tokens = append(tokens, xmlToken{kind: xmlText, raw: s[:end]})
+			s = s[end:]
+			continue
+		}
+
+		var end int
+		switch {
+		case strings.HasPrefix(s, "<!--"):
+			end = strings.Index(s, "-->") + len("-->")
+		case strings.HasPrefix(s, "<![CDATA["):
+			end = strings.Index(s, "]]>") + len("]]>")
+		case strings.HasPrefix(s, "<!"), strings.HasPrefix(s, "<?"):
+			end = strings.IndexByte(s, '>') + 1
+		default:
+			end = xmlTagEnd(s)
+		}
+		if end <= 0 {
+			return nil, false
+		}
+
+		raw := s[:end]
+		s = s[end:]
+
+		if raw[1] == '!' || raw[1] == '?' {
+			tokens = append(tokens, xmlToken{kind: xmlDirective, raw: raw})
+			continue
+		}
+
+		t := xmlToken{kind: xmlOpen, raw: raw}
+		name := raw[1:]
+		if name[0] == '/' {
+			t.kind = xmlClose
+			name = name[1:]
+		} else if strings.HasSuffix(raw, "/>") {
+			t.kind = xmlSelfClosing
+		}
+
+		t.name = name[:strings.IndexAny(name, " \t\r\n/>")]
+		if t.name == "" || !isXMLNameStart(t.name[0]) {
+			return nil, false
+		}
+
+		tokens = append(tokens, t)
+	}
+
+	return tokens, true
+}
+
+// xmlTagEnd returns the index after the '>' closing the tag at the start of s, skipping quoted attribute values
+func xmlTagEnd(s string) int {
+	var quote byte
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '<':
+			return -1
+		case c == '>':
+			return i + 1
+		}
+	}
+
+	return -1
+}
+
+func isXMLNameStart(c byte) bool {
+	return c == '_' || c == ':' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// isXML reports whether s is an XML or HTML document or fragment with balanced tags,
+// HTML void elements and unclosed elements like <p> are allowed
+func isXML(s string) bool {
+	s = strings.TrimSpace(s)
+	if len(s) < 3 || s[0] != '<' || s[len(s)-1] != '>' {
+		return false
+	}
+
+	tokens, ok := tokenizeXML(s)
+	if !ok {
+		return false
+	}
+
+	var stack []string
+	elements := 0
+	for _, t := range tokens {
+		switch t.kind {
+		case xmlOpen:
+			elements++
+			if !htmlVoidElements[strings.ToLower(t.name)] {
+				stack = append(stack, t.name)
+			}
+		case xmlSelfClosing:
+			elements++
+		case xmlClose:
+			i := len(stack) - 1
+			for i >= 0 && !strings.EqualFold(stack[i], t.name) {
+				i--
+			}
+			if i < 0 {
+				return false
+			}
+			stack = stack[:i]
+		}
+	}
+
+	return elements > 0
+}
+
+// isXMLValue checks if the value is an XML or HTML string
+func (h *Handler) isXMLValue(v slog.Value) bool {
+	return v.Kind() == slog.KindString && isXML(v.String())
+}
+
+// formatXML puts each element on its own line indented by its depth and highlights tags,
+// each line starts on a new line prefixed with indent. Input over MaxXMLSize is truncated.
+func (h *Handler) formatXML(s string, indent string) []byte {
+	s = strings.TrimSpace(s)
+	tokens, _ := tokenizeXML(s)
+
+	var b []byte
+	var stack []string
+	offset := 0
+	newLine := func(depth int) {
+		b = append(b, '\n')
+		b = append(b, indent...)
+		b = append(b, strings.Repeat("  ", depth)...)
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		if h.opts.MaxXMLSize > 0 && offset >= h.opts.MaxXMLSize {
+			newLine(len(stack))
+			b = append(b, h.faintedText([]byte(h.glyphs("… ")+strconv.Itoa(len(s)-offset)+" bytes truncated"))...)
+			break
+		}
+		offset += len(t.raw)
+
+		switch t.kind {
+		case xmlText:
+			text := strings.TrimSpace(t.raw)
+			if text == "" {
+				continue
+			}
+			newLine(len(stack))
+			b = append(b, h.colorString([]byte(text), fgWhite)...)
+		case xmlDirective:
+			newLine(len(stack))
+			b = append(b, h.faintedText([]byte(t.raw))...)
+		case xmlSelfClosing:
+			newLine(len(stack))
+			b = h.appendXMLTag(b, t.raw)
+		case xmlOpen:
+			newLine(len(stack))
+			b = h.appendXMLTag(b, t.raw)
+
+			// keep short text content on the line of its element: <name>text</name>
+			if i+2 < len(tokens) && tokens[i+1].kind == xmlText && tokens[i+2].kind == xmlClose &&
+				tokens[i+2].name == t.name && !strings.Contains(strings.TrimSpace(tokens[i+1].raw), "\n") {
+				b = append(b, h.colorString([]byte(strings.TrimSpace(tokens[i+1].raw)), fgWhite)...)
+				b = h.appendXMLTag(b, tokens[i+2].raw)
+				offset += len(tokens[i+1].raw) + len(tokens[i+2].raw)
+				i += 2
+				continue
+			}
+
+			if !htmlVoidElements[strings.ToLower(t.name)] {
+				stack = append(stack, t.name)
+			}
+		case xmlClose:
+			// unclosed elements like HTML <p> end with their parent
+			i := len(stack) - 1
+			for i >= 0 && !strings.EqualFold(stack[i], t.name) {
+				i--
+			}
+			if i >= 0 {
+				stack = stack[:i]
+			}
+			newLine(len(stack))
+			b = h.appendXMLTag(b, t.raw)
+		}
+	}
+
+	return b
+}
+
+// appendXMLTag appends a tag with the name and brackets in cyan and attribute names in gray
+func (h *Handler) appendXMLTag(b []byte, raw string) []byte {
+	prefix, suffix := "<", ">"
+	if strings.HasPrefix(raw, "</") {
+		prefix = "</"
+	}
+	if strings.HasSuffix(raw, "/>") {
+		suffix = "/>"
+	}
+
+	s := raw[len(prefix) : len(raw)-len(suffix)]
+	nameEnd := strings.IndexAny(s, " \t\r\n")
+	if nameEnd < 0 {
+		nameEnd = len(s)
+	}
+
+	b = append(b, h.colorString([]byte(prefix+s[:nameEnd]), fgCyan)...)
+
+	s = s[nameEnd:]
+	for {
+		s = strings.TrimLeft(s, " \t\r\n")
+		if s == "" {
+			break
+		}
+
+		end := strings.IndexAny(s, "= \t\r\n")
+		if end < 0 {
+			end = len(s)
+		}
+
+		b = append(b, ' ')
+		b = append(b, h.colorString([]byte(s[:end]), h.keyColor())...)
+		s = s[end:]
+
+		if !strings.HasPrefix(s, "=") {
+			continue
+		}
+
+		b = append(b, h.colorString([]byte("="), h.punctColor(fgWhite))...)
+		s = s[1:]
+
+		end = strings.IndexAny(s, " \t\r\n")
+		if s != "" && (s[0] == '"' || s[0] == '\'') {
+			end = strings.IndexByte(s[1:], s[0]) + 2
+		}
+		if end <= 0 || end > len(s) {
+			end = len(s)
+		}
+
+		b = append(b, h.colorString([]byte(s[:end]), fgWhite)...)
+		s = s[end:]
+	}
+
+	return append(b, h.colorString([]byte(suffix), fgCyan)...)
+}
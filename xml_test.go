@@ -0,0 +1,96 @@
+package humanslog
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+func Test_XML(t *testing.T) {
+	testIsXML(t)
+	testXMLIndent(t)
+	testHTMLIndent(t)
+	testXMLTruncate(t)
+	testXMLColors(t)
+}
+
+func testIsXML(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected bool
+	}{
+		{"<a><b>x</b></a>", true},
+		{`<?xml version="1.0"?><a x="1 > 0"/>`, true},
+		{"<div><p>one<br>two</div>", true},
+		{"<a>", true},
+		{"a < b > c", false},
+		{"<not closed", false},
+		{"<a></b>", false},
+		{"<>", false},
+		{"<1a></1a>", false},
+	}
+
+	for _, tt := range tests {
+		if got := isXML(tt.s); got != tt.expected {
+			t.Errorf("isXML(%q) = %v, expected %v", tt.s, got, tt.expected)
+		}
+	}
+}
+
+func testXMLIndent(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true}))
+
+	logger.Info("msg", slog.String("body", `<?xml version="1.0"?><soap:Envelope xmlns:soap="http://x"><soap:Body><!-- c --><m:Get id='1'><m:Name>Bob</m:Name><empty/></m:Get></soap:Body></soap:Envelope>`))
+
+	expected := "[]  INFO  msgX body=\n" +
+		"  <?xml version=\"1.0\"?>\n" +
+		"  <soap:Envelope xmlns:soap=\"http://x\">\n" +
+		"    <soap:Body>\n" +
+		"      <!-- c -->\n" +
+		"      <m:Get id='1'>\n" +
+		"        <m:Name>Bob</m:Name>\n" +
+		"        <empty/>\n" +
+		"      </m:Get>\n" +
+		"    </soap:Body>\n" +
+		"  </soap:Envelope>\n" +
+		"\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testHTMLIndent(t *testing.T) {
+	h := NewHandler(&MockWriter{}, &Options{NoColor: true})
+
+	got := h.formatXML("<div class=a><p>one<br>two<p>three</div>", "")
+	expected := "\n<div class=a>\n  <p>\n    one\n    <br>\n    two\n    <p>\n      three\n</div>"
+
+	if string(got) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, got)
+	}
+}
+
+func testXMLTruncate(t *testing.T) {
+	h := NewHandler(&MockWriter{}, &Options{NoColor: true, MaxXMLSize: 10})
+
+	got := h.formatXML("<a><b>1</b><b>2</b></a>", "")
+	expected := "\n<a>\n  <b>1</b>\n  … 12 bytes truncated"
+
+	if string(got) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, got)
+	}
+}
+
+func testXMLColors(t *testing.T) {
+	h := NewHandler(&MockWriter{}, nil)
+
+	got := h.formatXML(`<a href="x">y</a>`, "")
+	expected := "\n\x1b[36m<a\x1b[0m \x1b[90mhref\x1b[0m\x1b[37m=\x1b[0m\x1b[37m\"x\"\x1b[0m\x1b[36m>\x1b[0m" +
+		"\x1b[37my\x1b[0m\x1b[36m</a\x1b[0m\x1b[36m>\x1b[0m"
+
+	if string(got) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, got)
+	}
+}
@@ -0,0 +1,279 @@
+package humanslog
+
+import (
+	"strconv"
+	"strings"
+)
+
+// isYAML reports whether a multiline string looks like a YAML document: every line is a key, a list item,
+// a comment, a document marker or content of a block scalar, there is at least one key and some structure
+// (a "---" marker, a list item, a nested key or a block scalar), so plain "Error: x\nDetails: y" text isn't reformatted
+func isYAML(s string) bool {
+	if !strings.Contains(s, "\n") {
+		return false
+	}
+
+	keys, lines := 0, 0
+	structured := false
+	blockIndent := -1
+	for _, line := range strings.Split(s, "\n") {
+		trimmed := strings.TrimSpace(line)
+		indent := yamlIndent(line)
+
+		if blockIndent >= 0 {
+			if trimmed == "" || indent > blockIndent {
+				continue
+			}
+			blockIndent = -1
+		}
+
+		if trimmed == "---" {
+			structured = true
+			continue
+		}
+
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || trimmed == "..." {
+			continue
+		}
+
+		if strings.HasPrefix(line[indent:], "\t") {
+			return false
+		}
+
+		lines++
+
+		item, isListItem := yamlListItem(trimmed)
+		if isListItem || indent > 0 {
+			structured = true
+		}
+		if item == "" {
+			continue
+		}
+
+		_, value, ok := yamlKeyValue(item)
+		if !ok {
+			if isListItem {
+				continue
+			}
+			return false
+		}
+
+		keys++
+		if isYAMLBlockScalar(value) {
+			structured = true
+			blockIndent = indent
+		}
+	}
+
+	return keys > 0 && lines > 1 && structured
+}
+
+func yamlIndent(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// yamlListItem strips the "- " marker of a list item
+func yamlListItem(s string) (string, bool) {
+	if s == "-" {
+		return "", true
+	}
+
+	if strings.HasPrefix(s, "- ") {
+		return strings.TrimSpace(s[2:]), true
+	}
+
+	return s, false
+}
+
+// yamlKeyValue splits a "key: value" line, the key must be quoted or a single word
+func yamlKeyValue(s string) (key, value string, ok bool) {
+	var i int
+	if s != "" && (s[0] == '"' || s[0] == '\'') {
+		end := strings.IndexByte(s[1:], s[0])
+		if end < 0 {
+			return "", "", false
+		}
+		i = end + 2
+	} else {
+		i = strings.IndexByte(s, ':')
+		if i <= 0 {
+			return "", "", false
+		}
+
+		for _, c := range s[:i] {
+			if !isYAMLKeyRune(c) {
+				return "", "", false
+			}
+		}
+	}
+
+	if i >= len(s) || s[i] != ':' || (i+1 < len(s) && s[i+1] != ' ') {
+		return "", "", false
+	}
+
+	return s[:i], strings.TrimSpace(s[i+1:]), true
+}
+
+func isYAMLKeyRune(c rune) bool {
+	return c == '_' || c == '-' || c == '.' || c == '/' || c == '$' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c > 0x7f
+}
+
+func isYAMLBlockScalar(value string) bool {
+	return value != "" && (value[0] == '|' || value[0] == '>') && !strings.ContainsAny(value[1:], " \t")
+}
+
+// formatYAML re-indents a YAML document to two spaces per level and highlights it,
+// each line starts on a new line prefixed with indent
+func (h *Handler) formatYAML(s string, indent string) []byte {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	unit, base := yamlIndentUnit(lines)
+
+	var b []byte
+	blockIndent, blockNewIndent := -1, 0
+	for _, line := range lines {
+		b = append(b, '\n')
+
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+
+		oldIndent := yamlIndent(line)
+		b = append(b, indent...)
+
+		if blockIndent >= 0 && oldIndent > blockIndent {
+			// keep the relative indentation of block scalar content
+			b = append(b, strings.Repeat(" ", blockNewIndent+oldIndent-blockIndent)...)
+			b = append(b, h.colorString([]byte(trimmed), h.stringColor(fgWhite))...)
+			continue
+		}
+		blockIndent = -1
+
+		newIndent := max(oldIndent-base, 0) / unit * 2
+		b = append(b, strings.Repeat(" ", newIndent)...)
+
+		if isBlock := h.appendYAMLLine(&b, trimmed); isBlock {
+			blockIndent, blockNewIndent = oldIndent, newIndent
+		}
+	}
+
+	return b
+}
+
+// yamlIndentUnit returns the indentation of one level and the indentation of the top level
+func yamlIndentUnit(lines []string) (unit, base int) {
+	base = -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if i := yamlIndent(line); base < 0 || i < base {
+			base = i
+		}
+	}
+
+	unit = 0
+	blockIndent := -1
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+
+		i := yamlIndent(line)
+		if blockIndent >= 0 && i > blockIndent {
+			continue
+		}
+		blockIndent = -1
+
+		if d := i - base; d > 0 && (unit == 0 || d < unit) {
+			unit = d
+		}
+
+		item, _ := yamlListItem(trimmed)
+		if _, value, ok := yamlKeyValue(item); ok && isYAMLBlockScalar(value) {
+			blockIndent = i
+		}
+	}
+
+	return max(unit, 1), max(base, 0)
+}
+
+// appendYAMLLine appends a highlighted line without indentation, it reports whether the line starts a block scalar
+func (h *Handler) appendYAMLLine(b *[]byte, line string) bool {
+	switch {
+	case strings.HasPrefix(line, "#"):
+		*b = append(*b, h.faintedText([]byte(line))...)
+		return false
+	case line == "---" || line == "...":
+		*b = append(*b, h.colorString([]byte(line), fgCyan)...)
+		return false
+	}
+
+	item, isListItem := yamlListItem(line)
+	if isListItem {
+		*b = append(*b, h.colorString([]byte("-"), fgCyan)...)
+		if item == "" {
+			return false
+		}
+		*b = append(*b, ' ')
+	}
+
+	key, value, ok := yamlKeyValue(item)
+	if !ok {
+		*b = h.appendYAMLScalar(*b, item)
+		return false
+	}
+
+	*b = append(*b, h.colorString([]byte(key), h.keyColor())...)
+	*b = append(*b, h.colorString([]byte(":"), h.punctColor(fgWhite))...)
+	if value == "" {
+		return false
+	}
+
+	*b = append(*b, ' ')
+	if isYAMLBlockScalar(value) {
+		*b = append(*b, h.colorString([]byte(value), fgCyan)...)
+		return true
+	}
+
+	*b = h.appendYAMLScalar(*b, value)
+	return false
+}
+
+// appendYAMLScalar appends a value colored by its type, followed by a faint comment
+func (h *Handler) appendYAMLScalar(b []byte, s string) []byte {
+	var comment string
+	if s != "" && s[0] != '"' && s[0] != '\'' {
+		if i := strings.Index(s, " #"); i >= 0 {
+			s, comment = strings.TrimSpace(s[:i]), s[i:]
+		}
+	}
+
+	switch {
+	case s == "true":
+		b = append(b, h.colorString([]byte(s), fgGreen)...)
+	case s == "false":
+		b = append(b, h.colorString([]byte(s), fgRed)...)
+	case s == "null" || s == "~":
+		b = append(b, h.colorString([]byte(s), fgYellow)...)
+	case isYAMLNumber(s):
+		b = append(b, h.colorString([]byte(s), h.numberColor())...)
+	case s != "" && (s[0] == '{' || s[0] == '['):
+		b = append(b, h.colorString([]byte(s), fgCyan)...)
+	default:
+		b = append(b, h.colorString([]byte(s), h.stringColor(fgWhite))...)
+	}
+
+	if comment != "" {
+		b = append(b, h.faintedText([]byte(comment))...)
+	}
+
+	return b
+}
+
+func isYAMLNumber(s string) bool {
+	_, err := strconv.ParseFloat(strings.ReplaceAll(s, "_", ""), 64)
+	return err == nil
+}
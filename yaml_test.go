@@ -0,0 +1,72 @@
+package humanslog
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+func Test_YAML(t *testing.T) {
+	testIsYAML(t)
+	testYAMLReindent(t)
+	testYAMLColors(t)
+}
+
+func testIsYAML(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected bool
+	}{
+		{"a: 1\nb: 2", false},
+		{"---\na: 1\nb: 2", true},
+		{"server:\n  port: 1\nname: x", true},
+		{"Error: x\nDetails: y", false},
+		{"# config\nitems:\n  - a\n  - name: b", true},
+		{"script: |\n  echo: not a key\n  plain text\nnext: 1", true},
+		{"a: 1", false},
+		{"Error: something\nhappened here", false},
+		{"full name: x\nage: 1", false},
+		{"url: http://example.com\nb:2", false},
+		{"a:\n\tb: 1", false},
+	}
+
+	for _, tt := range tests {
+		if got := isYAML(tt.s); got != tt.expected {
+			t.Errorf("isYAML(%q) = %v, expected %v", tt.s, got, tt.expected)
+		}
+	}
+}
+
+func testYAMLReindent(t *testing.T) {
+	w := &MockWriter{}
+	logger := slog.New(NewHandler(w, &Options{TimeFormat: "[]", NoColor: true}))
+
+	logger.Info("msg", slog.String("cfg", "server:\n    port: 8080 # http\n    hosts:\n        - a\nscript: |\n    echo 1\n"))
+
+	expected := "[]  INFO  msgY cfg=\n" +
+		"  server:\n" +
+		"    port: 8080 # http\n" +
+		"    hosts:\n" +
+		"      - a\n" +
+		"  script: |\n" +
+		"      echo 1\n" +
+		"\n"
+
+	if !bytes.Equal(w.WrittenData, []byte(expected)) {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, w.WrittenData)
+	}
+}
+
+func testYAMLColors(t *testing.T) {
+	h := NewHandler(&MockWriter{}, nil)
+
+	got := h.formatYAML("on: true\nn: 1 # c\n- x", "")
+	expected := "\n" +
+		"\x1b[90mon\x1b[0m\x1b[37m:\x1b[0m \x1b[32mtrue\x1b[0m\n" +
+		"\x1b[90mn\x1b[0m\x1b[37m:\x1b[0m \x1b[36m1\x1b[0m\x1b[2m # c\x1b[0m\n" +
+		"\x1b[36m-\x1b[0m \x1b[37mx\x1b[0m"
+
+	if string(got) != expected {
+		t.Errorf("\nExpected:\n%s\nGot:\n%s\nExpected:\n%[1]q\nGot:\n%[2]q", expected, got)
+	}
+}